@@ -25,8 +25,8 @@ var (
 	cmdbCi           = "foobar"
 	assignedTo       = "foo"
 	location         = "foo bar"
-	impact           = "2"
-	urgency          = "2"
+	impact           = servicenow.ImpactMedium
+	urgency          = servicenow.UrgencyMedium
 )
 
 func main() {
@@ -71,21 +71,22 @@ func main() {
 		log.Fatal(err)
 	}
 
-	fmt.Println(inc.GetAssignmentGroup())
+	// With DisplayValue "true", reference fields hold display values instead of sys_ids.
+	fmt.Println(inc.GetAssignmentGroup().GetValue())
 
 	// Create a new Incident.
 	i := &servicenow.Incident{
-		CallerID:         &callerID,
-		ShortDescription: &shortDescription,
-		AssignmentGroup:  &assignmentGroup,
-		Description:      &description,
-		Category:         &category,
-		Subcategory:      &subCategory,
-		CmdbCi:           &cmdbCi,
-		AssignedTo:       &assignedTo,
-		Location:         &location,
-		Impact:           &impact,
-		Urgency:          &urgency,
+		CallerID:         servicenow.NewField(servicenow.Reference{DisplayValue: &callerID}),
+		ShortDescription: servicenow.NewField(shortDescription),
+		AssignmentGroup:  servicenow.NewField(servicenow.Reference{DisplayValue: &assignmentGroup}),
+		Description:      servicenow.NewField(description),
+		Category:         servicenow.NewField(category),
+		Subcategory:      servicenow.NewField(subCategory),
+		CmdbCi:           servicenow.NewField(servicenow.Reference{DisplayValue: &cmdbCi}),
+		AssignedTo:       servicenow.NewField(servicenow.Reference{DisplayValue: &assignedTo}),
+		Location:         servicenow.NewField(servicenow.Reference{DisplayValue: &location}),
+		Impact:           servicenow.NewField(impact),
+		Urgency:          servicenow.NewField(urgency),
 	}
 
 	inc, _, err = client.Incidents.Create(ctx, i, servicenow.CreateOptions{})
@@ -105,28 +106,30 @@ func main() {
 		fmt.Println(inc.GetNumber())
 	}
 
-	// Update and resolve an existing incident.
+	// Resolve an existing incident, sending only the fields that changed so
+	// that concurrent edits of other fields are not overwritten.
+	old, _, err := client.Incidents.Get(ctx, "INC12345678", servicenow.GetOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	newCallerId := "Bar Foo"
-	state := "6" // resolved
-	i = &servicenow.Incident{
-		CallerID:         &newCallerId,
-		ShortDescription: &shortDescription,
-		AssignmentGroup:  &assignmentGroup,
-		Description:      &description,
-		Category:         &category,
-		Subcategory:      &subCategory,
-		CmdbCi:           &cmdbCi,
-		AssignedTo:       &assignedTo,
-		Location:         &location,
-		Impact:           &impact,
-		Urgency:          &urgency,
-		State:            &state,
+	updated := *old
+	updated.CallerID = servicenow.NewField(servicenow.Reference{DisplayValue: &newCallerId})
+	updated.AssignedTo = servicenow.NullField[servicenow.Reference]() // unassign the incident
+	updated.State = servicenow.NewField(servicenow.IncidentStateResolved)
+
+	changes, err := servicenow.Diff(old, &updated)
+	if err != nil {
+		log.Fatal(err)
 	}
-	inc, _, err = client.Incidents.Update(ctx, "INC12345678", i, servicenow.UpdateOptions{})
+	fmt.Printf("Updating incident %s: %s\n", old.GetNumber(), changes)
+
+	inc, _, err = client.Incidents.UpdateFields(ctx, old.GetNumber(), changes.Patch(), servicenow.UpdateOptions{})
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("Incident %s has been updated with desc %s\n", inc.GetNumber(), inc.GetCallerID())
+	fmt.Printf("Incident %s has been updated with desc %s\n", inc.GetNumber(), inc.GetCallerID().GetValue())
 
 }
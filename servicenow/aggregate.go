@@ -0,0 +1,300 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// statsAPIPath is the path of the REST Aggregate API, relative to the
+// instance.
+const statsAPIPath = "/api/now/stats/"
+
+// AggregateService handles communication with the Aggregate API, which
+// computes statistics over the records of a table, such as the number of
+// incidents by priority, without listing them.
+//
+// The Aggregate API is a REST API; it is used regardless of the backend of
+// the record services.
+type AggregateService service
+
+// AggregateType is an aggregate function of the Aggregate API.
+type AggregateType string
+
+const (
+	AggregateCount AggregateType = "count"
+	AggregateMin   AggregateType = "min"
+	AggregateMax   AggregateType = "max"
+	AggregateAvg   AggregateType = "avg"
+	AggregateSum   AggregateType = "sum"
+)
+
+// Having filters groups on the value of an aggregate, such as the groups of
+// more than 10 records with Having{AggregateCount, "priority", Gt, "10"}.
+type Having struct {
+	Aggregate AggregateType
+	Field     string
+	Op        OperandType // One of Eq, Ne, Lt, Le, Gt or Ge
+	Value     string
+}
+
+// AggregateOptions specifies the records to aggregate and the statistics to
+// compute.
+type AggregateOptions struct {
+	// Query selects the records to aggregate.
+	Query *Query `url:"-"`
+
+	// QueryOpts are conditions ANDed to Query.
+	//
+	// Deprecated: Use Query, which supports more operators.
+	QueryOpts []QueryOpts `url:"-"`
+
+	// Count counts the records.
+	Count bool `url:"sysparm_count,omitempty"`
+
+	// MinFields, MaxFields, AvgFields and SumFields are the fields whose
+	// minimum, maximum, average and sum are computed.
+	MinFields []string `url:"sysparm_min_fields,comma,omitempty"`
+	MaxFields []string `url:"sysparm_max_fields,comma,omitempty"`
+	AvgFields []string `url:"sysparm_avg_fields,comma,omitempty"`
+	SumFields []string `url:"sysparm_sum_fields,comma,omitempty"`
+
+	// GroupBy computes the statistics for each distinct combination of values
+	// of the fields, rather than for all records.
+	GroupBy []string `url:"sysparm_group_by,comma,omitempty"`
+
+	// OrderBy orders the groups by group by fields or by aggregates, such as
+	// "COUNT" or "AVG^impact".
+	OrderBy []string `url:"sysparm_order_by,comma,omitempty"`
+
+	// Having filters the groups on their aggregates.
+	Having []Having `url:"-"`
+
+	DisplayValue DisplayValueType `url:"sysparm_display_value,omitempty"`
+}
+
+// aggregateParams holds the query parameters of an Aggregate API request.
+type aggregateParams struct {
+	AggregateOptions
+	Query  string `url:"sysparm_query,omitempty"`
+	Having string `url:"sysparm_having,omitempty"`
+}
+
+// AggregateResult holds the statistics of a group of records, or of all
+// records if no GroupBy fields were given.
+type AggregateResult struct {
+	Stats   AggregateStats `json:"stats"`
+	GroupBy []GroupByField `json:"groupby_fields,omitempty"`
+}
+
+func (r AggregateResult) String() string {
+	return Stringify(r)
+}
+
+// Group returns the value of the group by field of r, or an empty string if
+// r was not grouped by field.
+func (r *AggregateResult) Group(field string) string {
+	for _, f := range r.GroupBy {
+		if f.Field == field {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// GroupByField is the value of a group by field shared by the records of a
+// group.
+type GroupByField struct {
+	Field        string `json:"field"`
+	Value        string `json:"value"`
+	DisplayValue string `json:"display_value,omitempty"`
+}
+
+// AggregateStats are the statistics computed over a group of records. Min,
+// Max, Avg and Sum are keyed by field. Minimums and maximums are kept as
+// returned, since they may be computed over dates or strings.
+type AggregateStats struct {
+	Count int
+	Min   map[string]string
+	Max   map[string]string
+	Avg   map[string]float64
+	Sum   map[string]float64
+}
+
+func (s AggregateStats) String() string {
+	return Stringify(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The Aggregate API
+// returns all statistics as strings.
+func (s *AggregateStats) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Count string            `json:"count"`
+		Min   map[string]string `json:"min"`
+		Max   map[string]string `json:"max"`
+		Avg   map[string]string `json:"avg"`
+		Sum   map[string]string `json:"sum"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = AggregateStats{Min: raw.Min, Max: raw.Max}
+	if raw.Count != "" {
+		n, err := strconv.Atoi(raw.Count)
+		if err != nil {
+			return fmt.Errorf("invalid count %q: %v", raw.Count, err)
+		}
+		s.Count = n
+	}
+	var err error
+	if s.Avg, err = parseStats(raw.Avg); err != nil {
+		return err
+	}
+	s.Sum, err = parseStats(raw.Sum)
+	return err
+}
+
+// parseStats parses the numeric statistics of fields. Statistics over no
+// records are returned as empty strings, which are parsed as 0.
+func parseStats(fields map[string]string) (map[string]float64, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	stats := make(map[string]float64, len(fields))
+	for field, v := range fields {
+		if v == "" {
+			stats[field] = 0
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statistic %q of %s: %v", v, field, err)
+		}
+		stats[field] = f
+	}
+	return stats, nil
+}
+
+// Stats computes the statistics of opts over the records of table. It
+// returns one result per group, or a single result if opts.GroupBy is empty.
+//
+//	results, _, err := client.Aggregate.Stats(ctx, servicenow.IncidentTable, servicenow.AggregateOptions{
+//		Query:   servicenow.NewQuery().Eq("active", "true"),
+//		Count:   true,
+//		GroupBy: []string{servicenow.IncidentFieldPriority},
+//	})
+//	for _, r := range results {
+//		fmt.Println(servicenow.Priority(r.Group("priority")), r.Stats.Count)
+//	}
+func (s *AggregateService) Stats(ctx context.Context, table string, opts AggregateOptions) ([]*AggregateResult, *Response, error) {
+	if table == "" {
+		return nil, nil, fmt.Errorf("table cannot be empty")
+	}
+	if !opts.Count && len(opts.MinFields)+len(opts.MaxFields)+len(opts.AvgFields)+len(opts.SumFields) == 0 {
+		return nil, nil, fmt.Errorf("%s: no aggregate requested", table)
+	}
+	for _, fields := range [][]string{opts.MinFields, opts.MaxFields, opts.AvgFields, opts.SumFields, opts.GroupBy} {
+		for _, f := range fields {
+			if err := validateQueryField(f); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	params := aggregateParams{AggregateOptions: opts}
+	var err error
+	if params.Query, err = encodeQuery(opts.Query, opts.QueryOpts); err != nil {
+		return nil, nil, err
+	}
+	if params.Having, err = encodeHaving(opts.Having); err != nil {
+		return nil, nil, err
+	}
+	qs, err := query.Values(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	u := statsAPIPath + url.PathEscape(table) + "?" + qs.Encode()
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+	}
+	resp, err := s.client.Do(ctx, req, &res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	results, err := decodeAggregateResults(res.Result)
+	return results, resp, err
+}
+
+// decodeAggregateResults decodes the result of an Aggregate API response,
+// which is a single object if the statistics are not grouped, and an array
+// otherwise.
+func decodeAggregateResults(data json.RawMessage) ([]*AggregateResult, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	if data[0] == '{' {
+		var result AggregateResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		return []*AggregateResult{&result}, nil
+	}
+	var results []*AggregateResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Count returns the number of records of table that match q, which may be
+// nil to count all records.
+func (s *AggregateService) Count(ctx context.Context, table string, q *Query) (int, *Response, error) {
+	results, resp, err := s.Stats(ctx, table, AggregateOptions{Query: q, Count: true})
+	if err != nil || len(results) == 0 {
+		return 0, resp, err
+	}
+
+	return results[0].Stats.Count, resp, nil
+}
+
+// encodeHaving returns the value of sysparm_having for having, in which each
+// clause has the form aggregate^field^op^value and clauses are separated by
+// commas.
+func encodeHaving(having []Having) (string, error) {
+	clauses := make([]string, len(having))
+	for i, h := range having {
+		switch h.Aggregate {
+		case AggregateCount, AggregateMin, AggregateMax, AggregateAvg, AggregateSum:
+		default:
+			return "", &InvalidQueryError{Field: h.Field, Value: string(h.Aggregate), Reason: "is not an aggregate"}
+		}
+		switch h.Op {
+		case Eq, Ne, Lt, Le, Gt, Ge:
+		default:
+			return "", &InvalidQueryError{Field: h.Field, Value: string(h.Op), Reason: "is not a comparison operator"}
+		}
+		if err := validateQueryField(h.Field); err != nil {
+			return "", err
+		}
+		if strings.ContainsAny(h.Value, "^,") {
+			return "", &InvalidQueryError{Field: h.Field, Value: h.Value, Reason: "contains ^ or , which separate having clauses"}
+		}
+		clauses[i] = strings.Join([]string{string(h.Aggregate), h.Field, string(h.Op), h.Value}, "^")
+	}
+	return strings.Join(clauses, ","), nil
+}
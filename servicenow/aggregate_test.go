@@ -0,0 +1,224 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAggregateService_Stats(t *testing.T) {
+	client, mux := setup(t, WithDisplayLayout("01/02/2006 03:04:05 PM"))
+	mux.HandleFunc("/api/now/stats/incident", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "active=true")
+		q := r.URL.Query()
+		for k, want := range map[string]string{
+			"sysparm_count":         "true",
+			"sysparm_avg_fields":    "reassignment_count",
+			"sysparm_min_fields":    "opened_at",
+			"sysparm_group_by":      "priority,state",
+			"sysparm_order_by":      "COUNT",
+			"sysparm_having":        "count^priority^>^10,avg^reassignment_count^<=^2",
+			"sysparm_display_value": "true",
+		} {
+			if got := q.Get(k); got != want {
+				t.Errorf("%s = %q, want %q", k, got, want)
+			}
+		}
+		writeJSON(t, w, result([]map[string]interface{}{
+			{
+				"stats": map[string]interface{}{
+					"count": "12",
+					"avg":   map[string]string{"reassignment_count": "1.5"},
+					"min":   map[string]string{"opened_at": "01/02/2024 10:00:00 PM"},
+				},
+				"groupby_fields": []map[string]string{
+					{"field": "priority", "value": "1", "display_value": "1 - Critical"},
+					{"field": "state", "value": "2", "display_value": "In Progress"},
+				},
+			},
+			{
+				"stats": map[string]interface{}{
+					"count": "15",
+					"avg":   map[string]string{"reassignment_count": ""},
+					"min":   map[string]string{"opened_at": ""},
+				},
+				"groupby_fields": []map[string]string{
+					{"field": "priority", "value": "2", "display_value": "2 - High"},
+					{"field": "state", "value": "1", "display_value": "New"},
+				},
+			},
+		}))
+	})
+
+	results, _, err := client.Aggregate.Stats(context.Background(), IncidentTable, AggregateOptions{
+		Query:        NewQuery().Eq("active", "true"),
+		Count:        true,
+		AvgFields:    []string{"reassignment_count"},
+		MinFields:    []string{"opened_at"},
+		GroupBy:      []string{"priority", "state"},
+		OrderBy:      []string{"COUNT"},
+		Having:       []Having{{AggregateCount, "priority", Gt, "10"}, {AggregateAvg, "reassignment_count", Le, "2"}},
+		DisplayValue: DisplayValueTrue,
+	})
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	want := []*AggregateResult{
+		{
+			Stats: AggregateStats{
+				Count: 12,
+				Avg:   map[string]float64{"reassignment_count": 1.5},
+				Min:   map[string]string{"opened_at": "01/02/2024 10:00:00 PM"},
+			},
+			GroupBy: []GroupByField{
+				{Field: "priority", Value: "1", DisplayValue: "1 - Critical"},
+				{Field: "state", Value: "2", DisplayValue: "In Progress"},
+			},
+		},
+		{
+			Stats: AggregateStats{
+				Count: 15,
+				Avg:   map[string]float64{"reassignment_count": 0},
+				Min:   map[string]string{"opened_at": ""},
+			},
+			GroupBy: []GroupByField{
+				{Field: "priority", Value: "2", DisplayValue: "2 - High"},
+				{Field: "state", Value: "1", DisplayValue: "New"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Stats = %v, want %v", results, want)
+	}
+	if got := results[1].Group("state"); got != "1" {
+		t.Errorf("Group(state) = %q, want 1", got)
+	}
+	if got := results[1].Group("category"); got != "" {
+		t.Errorf("Group(category) = %q, want empty", got)
+	}
+}
+
+func TestAggregateService_Stats_invalid(t *testing.T) {
+	client, _ := setup(t)
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		table string
+		opts  AggregateOptions
+	}{
+		{"no table", "", AggregateOptions{Count: true}},
+		{"no aggregate", IncidentTable, AggregateOptions{GroupBy: []string{"priority"}}},
+		{"invalid field", IncidentTable, AggregateOptions{SumFields: []string{"a^b"}}},
+		{"invalid having", IncidentTable, AggregateOptions{Count: true, Having: []Having{{AggregateCount, "priority", NOTLIKE, "1"}}}},
+	}
+	for _, tt := range tests {
+		if _, _, err := client.Aggregate.Stats(ctx, tt.table, tt.opts); err == nil {
+			t.Errorf("Stats with %s returned no error", tt.name)
+		}
+	}
+}
+
+func TestAggregateService_Count(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/stats/incident", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "priority=1")
+		if got := r.URL.Query().Get("sysparm_count"); got != "true" {
+			t.Errorf("sysparm_count = %q, want true", got)
+		}
+		// Statistics that are not grouped are returned as a single object.
+		writeJSON(t, w, result(map[string]interface{}{"stats": map[string]string{"count": "42"}}))
+	})
+
+	n, _, err := client.Aggregate.Count(context.Background(), IncidentTable, NewQuery().Eq("priority", PriorityCritical))
+	if err != nil {
+		t.Fatalf("Count returned error: %v", err)
+	}
+	if n != 42 {
+		t.Errorf("Count = %d, want 42", n)
+	}
+}
+
+func TestAggregateService_Count_errorResponse(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/stats/incident", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(t, w, map[string]interface{}{"error": map[string]string{"message": "Invalid query"}, "status": "failure"})
+	})
+
+	_, _, err := client.Aggregate.Count(context.Background(), IncidentTable, nil)
+	var e *ErrorResponse
+	if !errors.As(err, &e) || e.Err.Message != "Invalid query" {
+		t.Errorf("Count returned error %v, want *ErrorResponse", err)
+	}
+}
+
+func TestEncodeHaving(t *testing.T) {
+	tests := []struct {
+		name    string
+		having  []Having
+		want    string
+		wantErr bool
+	}{
+		{name: "none", want: ""},
+		{name: "one", having: []Having{{AggregateSum, "reassignment_count", Ge, "3"}}, want: "sum^reassignment_count^>=^3"},
+		{
+			name:   "several",
+			having: []Having{{AggregateCount, "priority", Gt, "10"}, {AggregateMax, "opened_at", Lt, "2024-01-02"}},
+			want:   "count^priority^>^10,max^opened_at^<^2024-01-02",
+		},
+		{name: "invalid aggregate", having: []Having{{"median", "priority", Gt, "1"}}, wantErr: true},
+		{name: "invalid operator", having: []Having{{AggregateCount, "priority", NOTLIKE, "1"}}, wantErr: true},
+		{name: "invalid field", having: []Having{{AggregateCount, "a,b", Gt, "1"}}, wantErr: true},
+		{name: "caret", having: []Having{{AggregateCount, "priority", Gt, "1^2"}}, wantErr: true},
+		{name: "comma", having: []Having{{AggregateCount, "priority", Gt, "1,2"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := encodeHaving(tt.having)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("encodeHaving(%s) returned error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("encodeHaving(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateStats_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want AggregateStats
+	}{
+		{`{}`, AggregateStats{}},
+		{`{"count":"3"}`, AggregateStats{Count: 3}},
+		{
+			`{"count":"0","avg":{"impact":"2.3333","urgency":""},"sum":{"reassignment_count":"7"},"max":{"opened_at":"2024-01-02 03:04:05"}}`,
+			AggregateStats{
+				Avg: map[string]float64{"impact": 2.3333, "urgency": 0},
+				Sum: map[string]float64{"reassignment_count": 7},
+				Max: map[string]string{"opened_at": "2024-01-02 03:04:05"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		var got AggregateStats
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Errorf("Unmarshal(%s) returned error: %v", tt.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+
+	for _, data := range []string{`{"count":"many"}`, `{"avg":{"impact":"high"}}`, `{"sum":{"impact":"1,5"}}`, `{"count":3}`} {
+		if err := json.Unmarshal([]byte(data), new(AggregateStats)); err == nil {
+			t.Errorf("Unmarshal(%s) returned no error", data)
+		}
+	}
+}
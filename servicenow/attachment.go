@@ -0,0 +1,163 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+)
+
+// attachmentAPIPath is the path of the REST Attachment API, relative to the
+// instance.
+const attachmentAPIPath = "/api/now/attachment"
+
+// AttachmentsService handles communication with the Attachment API, which
+// manages the files attached to the records of any table.
+//
+// The Attachment API is a REST API; it is used regardless of the backend of
+// the record services.
+type AttachmentsService service
+
+// Attachment is the metadata of a file attached to a record.
+type Attachment struct {
+	SysID          *string    `json:"sys_id,omitempty"`
+	FileName       *string    `json:"file_name,omitempty"`
+	ContentType    *string    `json:"content_type,omitempty"`
+	SizeBytes      *string    `json:"size_bytes,omitempty"`
+	SizeCompressed *string    `json:"size_compressed,omitempty"`
+	Compressed     *string    `json:"compressed,omitempty"`
+	Hash           *string    `json:"hash,omitempty"`
+	TableName      *string    `json:"table_name,omitempty"`
+	TableSysID     *string    `json:"table_sys_id,omitempty"`
+	DownloadLink   *string    `json:"download_link,omitempty"`
+	SysCreatedBy   *string    `json:"sys_created_by,omitempty"`
+	SysCreatedOn   *Timestamp `json:"sys_created_on,omitempty"`
+	SysUpdatedBy   *string    `json:"sys_updated_by,omitempty"`
+	SysUpdatedOn   *Timestamp `json:"sys_updated_on,omitempty"`
+}
+
+func (a Attachment) String() string {
+	return Stringify(a)
+}
+
+// Upload attaches the content read from r, named filename, to the record
+// sysID of table. contentType is the MIME type of the content, such as
+// "image/png", and defaults to application/octet-stream. The content is
+// streamed to the instance rather than buffered in memory; its length is
+// sent when r is an *os.File or an in-memory reader.
+func (s *AttachmentsService) Upload(ctx context.Context, table, sysID, filename, contentType string, r io.Reader) (*Attachment, *Response, error) {
+	if table == "" || sysID == "" {
+		return nil, nil, fmt.Errorf("table and sys_id cannot be empty")
+	}
+	if filename == "" {
+		return nil, nil, fmt.Errorf("filename cannot be empty")
+	}
+
+	size := int64(-1)
+	if f, ok := r.(*os.File); ok {
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			if off, err := f.Seek(0, io.SeekCurrent); err == nil {
+				size = fi.Size() - off
+			}
+		}
+	}
+
+	params := url.Values{}
+	params.Set("table_name", table)
+	params.Set("table_sys_id", sysID)
+	params.Set("file_name", filename)
+	req, err := s.client.NewUploadRequest(attachmentAPIPath+"/file?"+params.Encode(), r, size, contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res struct {
+		Result *Attachment `json:"result"`
+	}
+	resp, err := s.client.Do(ctx, req, &res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.Result, resp, nil
+}
+
+// List lists the attachments of the record sysID of table.
+func (s *AttachmentsService) List(ctx context.Context, table, sysID string) ([]*Attachment, *Response, error) {
+	if table == "" || sysID == "" {
+		return nil, nil, fmt.Errorf("table and sys_id cannot be empty")
+	}
+	q, err := NewQuery().Eq("table_name", table).Eq("table_sys_id", sysID).Encode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", attachmentAPIPath+"?"+url.Values{"sysparm_query": {q}}.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res struct {
+		Result []*Attachment `json:"result"`
+	}
+	resp, err := s.client.Do(ctx, req, &res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.Result, resp, nil
+}
+
+// Get returns the metadata of the attachment sysID.
+func (s *AttachmentsService) Get(ctx context.Context, sysID string) (*Attachment, *Response, error) {
+	if sysID == "" {
+		return nil, nil, fmt.Errorf("attachment sys_id cannot be empty")
+	}
+
+	req, err := s.client.NewRequest("GET", attachmentAPIPath+"/"+url.PathEscape(sysID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res struct {
+		Result *Attachment `json:"result"`
+	}
+	resp, err := s.client.Do(ctx, req, &res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.Result, resp, nil
+}
+
+// Download writes the content of the attachment sysID to w, as it is read
+// from the instance.
+func (s *AttachmentsService) Download(ctx context.Context, sysID string, w io.Writer) (*Response, error) {
+	if sysID == "" {
+		return nil, fmt.Errorf("attachment sys_id cannot be empty")
+	}
+
+	req, err := s.client.NewRequest("GET", attachmentAPIPath+"/"+url.PathEscape(sysID)+"/file", nil)
+	if err != nil {
+		return nil, err
+	}
+	// The content is returned with its own content type.
+	req.Header.Set("Accept", "*/*")
+
+	return s.client.Do(ctx, req, w)
+}
+
+// Delete deletes the attachment sysID.
+func (s *AttachmentsService) Delete(ctx context.Context, sysID string) (*Response, error) {
+	if sysID == "" {
+		return nil, fmt.Errorf("attachment sys_id cannot be empty")
+	}
+
+	req, err := s.client.NewRequest("DELETE", attachmentAPIPath+"/"+url.PathEscape(sysID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
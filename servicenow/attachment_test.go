@@ -0,0 +1,245 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// attachmentJSON is the metadata of attachment a1 as returned by the
+// Attachment API.
+var attachmentJSON = map[string]interface{}{
+	"sys_id":         "a1",
+	"file_name":      "log.txt",
+	"content_type":   "text/plain",
+	"size_bytes":     "5",
+	"table_name":     "incident",
+	"table_sys_id":   "s1",
+	"download_link":  "https://instance/api/now/attachment/a1/file",
+	"sys_created_on": "2024-01-02 03:04:05",
+}
+
+func wantAttachment() *Attachment {
+	return &Attachment{
+		SysID:        stringPtr("a1"),
+		FileName:     stringPtr("log.txt"),
+		ContentType:  stringPtr("text/plain"),
+		SizeBytes:    stringPtr("5"),
+		TableName:    stringPtr("incident"),
+		TableSysID:   stringPtr("s1"),
+		DownloadLink: stringPtr("https://instance/api/now/attachment/a1/file"),
+		SysCreatedOn: &Timestamp{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+}
+
+// uploadHandler checks an upload of content to record s1 of incident,
+// named log.txt, with the given content type and length.
+func uploadHandler(t *testing.T, content, contentType string, length int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		q := r.URL.Query()
+		for k, want := range map[string]string{"table_name": "incident", "table_sys_id": "s1", "file_name": "log.txt"} {
+			if got := q.Get(k); got != want {
+				t.Errorf("%s = %q, want %q", k, got, want)
+			}
+		}
+		if got := r.Header.Get("Content-Type"); got != contentType {
+			t.Errorf("Content-Type = %q, want %q", got, contentType)
+		}
+		if r.ContentLength != length {
+			t.Errorf("ContentLength = %d, want %d", r.ContentLength, length)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading body: %v", err)
+		}
+		if got := string(body); got != content {
+			t.Errorf("body = %q, want %q", got, content)
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(t, w, result(attachmentJSON))
+	}
+}
+
+func TestAttachmentsService_Upload_file(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/attachment/file", uploadHandler(t, "hello", "text/plain", 5))
+
+	name := filepath.Join(t.TempDir(), "log.txt")
+	if err := os.WriteFile(name, []byte("skip:hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	// The size is that of the content left to read.
+	if _, err := f.Seek(5, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	a, _, err := client.Attachments.Upload(context.Background(), IncidentTable, "s1", "log.txt", "text/plain", f)
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if want := wantAttachment(); !reflect.DeepEqual(a, want) {
+		t.Errorf("Upload = %v, want %v", a, want)
+	}
+}
+
+func TestAttachmentsService_Upload_reader(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/attachment/file", uploadHandler(t, "hello", defaultMediaType, 5))
+
+	if _, _, err := client.Attachments.Upload(context.Background(), IncidentTable, "s1", "log.txt", "", bytes.NewReader([]byte("hello"))); err != nil {
+		t.Errorf("Upload returned error: %v", err)
+	}
+}
+
+func TestAttachmentsService_Upload_streaming(t *testing.T) {
+	client, mux := setup(t)
+	received := make(chan struct{})
+	mux.HandleFunc("/api/now/attachment/file", func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("ContentLength = %d, want -1 for content of unknown length", r.ContentLength)
+		}
+		first := make([]byte, 3)
+		if _, err := io.ReadFull(r.Body, first); err != nil {
+			t.Errorf("reading body: %v", err)
+		}
+		close(received)
+		rest, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading body: %v", err)
+		}
+		if got := string(first) + string(rest); got != "hello" {
+			t.Errorf("body = %q, want hello", got)
+		}
+		writeJSON(t, w, result(attachmentJSON))
+	})
+
+	// The rest of the content is written only once the instance has received
+	// its start, which it would not if the content were buffered.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("hel"))
+		select {
+		case <-received:
+			pw.Write([]byte("lo"))
+			pw.Close()
+		case <-time.After(5 * time.Second):
+			pw.CloseWithError(io.ErrUnexpectedEOF)
+		}
+	}()
+
+	if _, _, err := client.Attachments.Upload(context.Background(), IncidentTable, "s1", "log.txt", "", pr); err != nil {
+		t.Errorf("Upload returned error: %v", err)
+	}
+}
+
+func TestAttachmentsService_Upload_invalid(t *testing.T) {
+	client, _ := setup(t)
+	ctx := context.Background()
+	for _, args := range [][3]string{{"", "s1", "log.txt"}, {IncidentTable, "", "log.txt"}, {IncidentTable, "s1", ""}} {
+		if _, _, err := client.Attachments.Upload(ctx, args[0], args[1], args[2], "", bytes.NewReader(nil)); err == nil {
+			t.Errorf("Upload(%q, %q, %q) returned no error", args[0], args[1], args[2])
+		}
+	}
+}
+
+func TestAttachmentsService_List(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/attachment", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "table_name=incident^table_sys_id=s1")
+		writeJSON(t, w, result([]interface{}{attachmentJSON}))
+	})
+
+	attachments, _, err := client.Attachments.List(context.Background(), IncidentTable, "s1")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if want := []*Attachment{wantAttachment()}; !reflect.DeepEqual(attachments, want) {
+		t.Errorf("List = %v, want %v", attachments, want)
+	}
+
+	if _, _, err := client.Attachments.List(context.Background(), IncidentTable, ""); err == nil {
+		t.Errorf("List with an empty sys_id returned no error")
+	}
+}
+
+func TestAttachmentsService_Get(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/attachment/a1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		writeJSON(t, w, result(attachmentJSON))
+	})
+
+	a, _, err := client.Attachments.Get(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if want := wantAttachment(); !reflect.DeepEqual(a, want) {
+		t.Errorf("Get = %v, want %v", a, want)
+	}
+
+	if _, _, err := client.Attachments.Get(context.Background(), ""); err == nil {
+		t.Errorf("Get with an empty sys_id returned no error")
+	}
+}
+
+func TestAttachmentsService_Download(t *testing.T) {
+	client, mux := setup(t)
+	content := []byte{0x89, 'P', 'N', 'G', 0, 1, 2}
+	mux.HandleFunc("/api/now/attachment/a1/file", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.Header.Get("Accept"); got != "*/*" {
+			t.Errorf("Accept = %q, want */*", got)
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(content)
+	})
+
+	var buf bytes.Buffer
+	resp, err := client.Attachments.Download(context.Background(), "a1", &buf)
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), content) {
+		t.Errorf("Download wrote %v, want %v", buf.Bytes(), content)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "image/png" {
+		t.Errorf("Content-Type = %q, want image/png", got)
+	}
+
+	if _, err := client.Attachments.Download(context.Background(), "", &buf); err == nil {
+		t.Errorf("Download with an empty sys_id returned no error")
+	}
+}
+
+func TestAttachmentsService_Delete(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/now/attachment/a1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Attachments.Delete(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	if _, err := client.Attachments.Delete(context.Background(), ""); err == nil {
+		t.Errorf("Delete with an empty sys_id returned no error")
+	}
+}
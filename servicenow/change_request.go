@@ -1,270 +1,127 @@
 package servicenow
 
-import (
-	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"strings"
-)
+import "encoding/json"
+
+// ChangeRequestTable is the name of the table of change requests.
+const ChangeRequestTable = "change_request"
 
 // ChangeRequestsService handles the communication with the ChangeRequest related
 // methods of the ServiceNow API.
-type ChangeRequestsService service
+type ChangeRequestsService struct {
+	*TableService[ChangeRequest]
+}
 
 // ChangeRequest represents a ServiceNow change.
 type ChangeRequest struct {
-	Status                         *string `json:"__status,omitempty"`
-	Active                         *string `json:"active,omitempty"`
-	ActivityDue                    *string `json:"activity_due,omitempty"`
-	AdditionalAssigneeList         *string `json:"additional_assignee_list,omitempty"`
-	Approval                       *string `json:"approval,omitempty"`
-	ApprovalHistory                *string `json:"approval_history,omitempty"`
-	ApprovalSet                    *string `json:"approval_set,omitempty"`
-	AssignedTo                     *string `json:"assigned_to,omitempty"`
-	AssignmentGroup                *string `json:"assignment_group,omitempty"`
-	BackoutPlan                    *string `json:"backout_plan,omitempty"`
-	BusinessDuration               *string `json:"business_duration,omitempty"`
-	BusinessService                *string `json:"business_service,omitempty"`
-	CabDate                        *string `json:"cab_date,omitempty"`
-	CabDelegate                    *string `json:"cab_delegate,omitempty"`
-	CabRecommendation              *string `json:"cab_recommendation,omitempty"`
-	CabRequired                    *string `json:"cab_required,omitempty"`
-	CalendarDuration               *string `json:"calendar_duration,omitempty"`
-	Category                       *string `json:"category,omitempty"`
-	ChangePlan                     *string `json:"change_plan,omitempty"`
-	ChgModel                       *string `json:"chg_model,omitempty"`
-	CloseCode                      *string `json:"close_code,omitempty"`
-	CloseNotes                     *string `json:"close_notes,omitempty"`
-	ClosedAt                       *string `json:"closed_at,omitempty"`
-	ClosedBy                       *string `json:"closed_by,omitempty"`
-	CmdbCi                         *string `json:"cmdb_ci,omitempty"`
-	Comments                       *string `json:"comments,omitempty"`
-	CommentsAndWorkNotes           *string `json:"comments_and_work_notes,omitempty"`
-	Company                        *string `json:"company,omitempty"`
-	ConflictLastRun                *string `json:"conflict_last_run,omitempty"`
-	ConflictStatus                 *string `json:"conflict_status,omitempty"`
-	ContactType                    *string `json:"contact_type,omitempty"`
-	CorrelationDisplay             *string `json:"correlation_display,omitempty"`
-	CorrelationID                  *string `json:"correlation_id,omitempty"`
-	Description                    *string `json:"description,omitempty"`
-	DueDate                        *string `json:"due_date,omitempty"`
-	EndDate                        *string `json:"end_date,omitempty"`
-	Escalation                     *string `json:"escalation,omitempty"`
-	ExpectedStart                  *string `json:"expected_start,omitempty"`
-	FollowUp                       *string `json:"follow_up,omitempty"`
-	GroupList                      *string `json:"group_list,omitempty"`
-	Impact                         *string `json:"impact,omitempty"`
-	ImplementationPlan             *string `json:"implementation_plan,omitempty"`
-	Justification                  *string `json:"justification,omitempty"`
-	Knowledge                      *string `json:"knowledge,omitempty"`
-	Location                       *string `json:"location,omitempty"`
-	MadeSLA                        *string `json:"made_sla,omitempty"`
-	Number                         *string `json:"number,omitempty"`
-	OnHold                         *string `json:"on_hold,omitempty"`
-	OnHoldReason                   *string `json:"on_hold_reason,omitempty"`
-	OnHoldTask                     *string `json:"on_hold_task,omitempty"`
-	OpenedAt                       *string `json:"opened_at,omitempty"`
-	OpenedBy                       *string `json:"opened_by,omitempty"`
-	Order                          *string `json:"order,omitempty"`
-	OutsideMaintenanceSchedule     *string `json:"outside_maintenance_schedule,omitempty"`
-	Parent                         *string `json:"parent,omitempty"`
-	Phase                          *string `json:"phase,omitempty"`
-	PhaseState                     *string `json:"phase_state,omitempty"`
-	Priority                       *string `json:"priority,omitempty"`
-	ProductionSystem               *string `json:"production_system,omitempty"`
-	Reason                         *string `json:"reason,omitempty"`
-	ReassignmentCount              *string `json:"reassignment_count,omitempty"`
-	RequestedBy                    *string `json:"requested_by,omitempty"`
-	RequestedByDate                *string `json:"requested_by_date,omitempty"`
-	ReviewComments                 *string `json:"review_comments,omitempty"`
-	ReviewDate                     *string `json:"review_date,omitempty"`
-	ReviewStatus                   *string `json:"review_status,omitempty"`
-	Risk                           *string `json:"risk,omitempty"`
-	RiskImpactAnalysis             *string `json:"risk_impact_analysis,omitempty"`
-	RiskValue                      *string `json:"risk_value,omitempty"`
-	RouteReason                    *string `json:"route_reason,omitempty"`
-	Scope                          *string `json:"scope,omitempty"`
-	ServiceOffering                *string `json:"service_offering,omitempty"`
-	ShortDescription               *string `json:"short_description,omitempty"`
-	Skills                         *string `json:"skills,omitempty"`
-	SLADue                         *string `json:"sla_due,omitempty"`
-	SnEsignDocument                *string `json:"sn_esign_document,omitempty"`
-	SnEsignEsignatureConfiguration *string `json:"sn_esign_esignature_configuration,omitempty"`
-	StartDate                      *string `json:"start_date,omitempty"`
-	State                          *string `json:"state,omitempty"`
-	StdChangeProducerVersion       *string `json:"std_change_producer_version,omitempty"`
-	SysClassName                   *string `json:"sys_class_name,omitempty"`
-	SysCreatedBy                   *string `json:"sys_created_by,omitempty"`
-	SysCreatedOn                   *string `json:"sys_created_on,omitempty"`
-	SysDomain                      *string `json:"sys_domain,omitempty"`
-	SysDomainPath                  *string `json:"sys_domain_path,omitempty"`
-	SysID                          *string `json:"sys_id,omitempty"`
-	SysModCount                    *string `json:"sys_mod_count,omitempty"`
-	SysTags                        *string `json:"sys_tags,omitempty"`
-	SysUpdatedBy                   *string `json:"sys_updated_by,omitempty"`
-	SysUpdatedOn                   *string `json:"sys_updated_on,omitempty"`
-	TaskEffectiveNumber            *string `json:"task_effective_number,omitempty"`
-	TestPlan                       *string `json:"test_plan,omitempty"`
-	TimeWorked                     *string `json:"time_worked,omitempty"`
-	Type                           *string `json:"type,omitempty"`
-	Unauthorized                   *string `json:"unauthorized,omitempty"`
-	UniversalRequest               *string `json:"universal_request,omitempty"`
-	UponApproval                   *string `json:"upon_approval,omitempty"`
-	UponReject                     *string `json:"upon_reject,omitempty"`
-	Urgency                        *string `json:"urgency,omitempty"`
-	UserInput                      *string `json:"user_input,omitempty"`
-	WatchList                      *string `json:"watch_list,omitempty"`
-	WorkEnd                        *string `json:"work_end,omitempty"`
-	WorkNotes                      *string `json:"work_notes,omitempty"`
-	WorkNotesList                  *string `json:"work_notes_list,omitempty"`
-	WorkStart                      *string `json:"work_start,omitempty"`
-
-	Extra map[string]string `json:"-"`
+	Status                         *Field[string]      `json:"__status,omitempty"`
+	Active                         *Field[string]      `json:"active,omitempty"`
+	ActivityDue                    *Field[Timestamp]   `json:"activity_due,omitempty"`
+	AdditionalAssigneeList         *Field[string]      `json:"additional_assignee_list,omitempty"`
+	Approval                       *Field[string]      `json:"approval,omitempty"`
+	ApprovalHistory                *Field[string]      `json:"approval_history,omitempty"`
+	ApprovalSet                    *Field[Timestamp]   `json:"approval_set,omitempty"`
+	AssignedTo                     *Field[Reference]   `json:"assigned_to,omitempty"`
+	AssignmentGroup                *Field[Reference]   `json:"assignment_group,omitempty"`
+	BackoutPlan                    *Field[string]      `json:"backout_plan,omitempty"`
+	BusinessDuration               *Field[string]      `json:"business_duration,omitempty"`
+	BusinessService                *Field[Reference]   `json:"business_service,omitempty"`
+	CabDate                        *Field[Timestamp]   `json:"cab_date,omitempty"`
+	CabDelegate                    *Field[Reference]   `json:"cab_delegate,omitempty"`
+	CabRecommendation              *Field[string]      `json:"cab_recommendation,omitempty"`
+	CabRequired                    *Field[string]      `json:"cab_required,omitempty"`
+	CalendarDuration               *Field[string]      `json:"calendar_duration,omitempty"`
+	Category                       *Field[string]      `json:"category,omitempty"`
+	ChangePlan                     *Field[string]      `json:"change_plan,omitempty"`
+	ChgModel                       *Field[Reference]   `json:"chg_model,omitempty"`
+	CloseCode                      *Field[string]      `json:"close_code,omitempty"`
+	CloseNotes                     *Field[string]      `json:"close_notes,omitempty"`
+	ClosedAt                       *Field[Timestamp]   `json:"closed_at,omitempty"`
+	ClosedBy                       *Field[Reference]   `json:"closed_by,omitempty"`
+	CmdbCi                         *Field[Reference]   `json:"cmdb_ci,omitempty"`
+	Comments                       *Field[string]      `json:"comments,omitempty"`
+	CommentsAndWorkNotes           *Field[string]      `json:"comments_and_work_notes,omitempty"`
+	Company                        *Field[Reference]   `json:"company,omitempty"`
+	ConflictLastRun                *Field[Timestamp]   `json:"conflict_last_run,omitempty"`
+	ConflictStatus                 *Field[string]      `json:"conflict_status,omitempty"`
+	ContactType                    *Field[string]      `json:"contact_type,omitempty"`
+	CorrelationDisplay             *Field[string]      `json:"correlation_display,omitempty"`
+	CorrelationID                  *Field[string]      `json:"correlation_id,omitempty"`
+	Description                    *Field[string]      `json:"description,omitempty"`
+	DueDate                        *Field[Timestamp]   `json:"due_date,omitempty"`
+	EndDate                        *Field[Timestamp]   `json:"end_date,omitempty"`
+	Escalation                     *Field[string]      `json:"escalation,omitempty"`
+	ExpectedStart                  *Field[Timestamp]   `json:"expected_start,omitempty"`
+	FollowUp                       *Field[Timestamp]   `json:"follow_up,omitempty"`
+	GroupList                      *Field[string]      `json:"group_list,omitempty"`
+	Impact                         *Field[Impact]      `json:"impact,omitempty"`
+	ImplementationPlan             *Field[string]      `json:"implementation_plan,omitempty"`
+	Justification                  *Field[string]      `json:"justification,omitempty"`
+	Knowledge                      *Field[string]      `json:"knowledge,omitempty"`
+	Location                       *Field[Reference]   `json:"location,omitempty"`
+	MadeSLA                        *Field[string]      `json:"made_sla,omitempty"`
+	Number                         *Field[string]      `json:"number,omitempty"`
+	OnHold                         *Field[string]      `json:"on_hold,omitempty"`
+	OnHoldReason                   *Field[string]      `json:"on_hold_reason,omitempty"`
+	OnHoldTask                     *Field[string]      `json:"on_hold_task,omitempty"`
+	OpenedAt                       *Field[Timestamp]   `json:"opened_at,omitempty"`
+	OpenedBy                       *Field[Reference]   `json:"opened_by,omitempty"`
+	Order                          *Field[string]      `json:"order,omitempty"`
+	OutsideMaintenanceSchedule     *Field[string]      `json:"outside_maintenance_schedule,omitempty"`
+	Parent                         *Field[Reference]   `json:"parent,omitempty"`
+	Phase                          *Field[string]      `json:"phase,omitempty"`
+	PhaseState                     *Field[string]      `json:"phase_state,omitempty"`
+	Priority                       *Field[Priority]    `json:"priority,omitempty"`
+	ProductionSystem               *Field[string]      `json:"production_system,omitempty"`
+	Reason                         *Field[string]      `json:"reason,omitempty"`
+	ReassignmentCount              *Field[string]      `json:"reassignment_count,omitempty"`
+	RequestedBy                    *Field[Reference]   `json:"requested_by,omitempty"`
+	RequestedByDate                *Field[Timestamp]   `json:"requested_by_date,omitempty"`
+	ReviewComments                 *Field[string]      `json:"review_comments,omitempty"`
+	ReviewDate                     *Field[Timestamp]   `json:"review_date,omitempty"`
+	ReviewStatus                   *Field[string]      `json:"review_status,omitempty"`
+	Risk                           *Field[string]      `json:"risk,omitempty"`
+	RiskImpactAnalysis             *Field[string]      `json:"risk_impact_analysis,omitempty"`
+	RiskValue                      *Field[string]      `json:"risk_value,omitempty"`
+	RouteReason                    *Field[string]      `json:"route_reason,omitempty"`
+	Scope                          *Field[string]      `json:"scope,omitempty"`
+	ServiceOffering                *Field[Reference]   `json:"service_offering,omitempty"`
+	ShortDescription               *Field[string]      `json:"short_description,omitempty"`
+	Skills                         *Field[string]      `json:"skills,omitempty"`
+	SLADue                         *Field[Timestamp]   `json:"sla_due,omitempty"`
+	SnEsignDocument                *Field[string]      `json:"sn_esign_document,omitempty"`
+	SnEsignEsignatureConfiguration *Field[string]      `json:"sn_esign_esignature_configuration,omitempty"`
+	StartDate                      *Field[Timestamp]   `json:"start_date,omitempty"`
+	State                          *Field[ChangeState] `json:"state,omitempty"`
+	StdChangeProducerVersion       *Field[Reference]   `json:"std_change_producer_version,omitempty"`
+	SysClassName                   *Field[string]      `json:"sys_class_name,omitempty"`
+	SysCreatedBy                   *Field[string]      `json:"sys_created_by,omitempty"`
+	SysCreatedOn                   *Field[Timestamp]   `json:"sys_created_on,omitempty"`
+	SysDomain                      *Field[Reference]   `json:"sys_domain,omitempty"`
+	SysDomainPath                  *Field[string]      `json:"sys_domain_path,omitempty"`
+	SysID                          *Field[string]      `json:"sys_id,omitempty"`
+	SysModCount                    *Field[string]      `json:"sys_mod_count,omitempty"`
+	SysTags                        *Field[string]      `json:"sys_tags,omitempty"`
+	SysUpdatedBy                   *Field[string]      `json:"sys_updated_by,omitempty"`
+	SysUpdatedOn                   *Field[Timestamp]   `json:"sys_updated_on,omitempty"`
+	TaskEffectiveNumber            *Field[string]      `json:"task_effective_number,omitempty"`
+	TestPlan                       *Field[string]      `json:"test_plan,omitempty"`
+	TimeWorked                     *Field[string]      `json:"time_worked,omitempty"`
+	Type                           *Field[string]      `json:"type,omitempty"`
+	Unauthorized                   *Field[string]      `json:"unauthorized,omitempty"`
+	UniversalRequest               *Field[Reference]   `json:"universal_request,omitempty"`
+	UponApproval                   *Field[string]      `json:"upon_approval,omitempty"`
+	UponReject                     *Field[string]      `json:"upon_reject,omitempty"`
+	Urgency                        *Field[Urgency]     `json:"urgency,omitempty"`
+	UserInput                      *Field[string]      `json:"user_input,omitempty"`
+	WatchList                      *Field[string]      `json:"watch_list,omitempty"`
+	WorkEnd                        *Field[Timestamp]   `json:"work_end,omitempty"`
+	WorkNotes                      *Field[string]      `json:"work_notes,omitempty"`
+	WorkNotesList                  *Field[string]      `json:"work_notes_list,omitempty"`
+	WorkStart                      *Field[Timestamp]   `json:"work_start,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // Fields without a corresponding struct field
 }
 
 func (c ChangeRequest) String() string {
 	return Stringify(c)
 }
-
-func (c ChangeRequest) MarshalJSON() ([]byte, error) {
-	type changeRequest ChangeRequest
-	b, _ := json.Marshal(changeRequest(c))
-
-	var m map[string]json.RawMessage
-	_ = json.Unmarshal(b, &m)
-
-	for k, v := range c.Extra {
-		b, _ := json.Marshal(v)
-		m[k] = b
-	}
-
-	return json.Marshal(m)
-}
-
-// List change requests
-func (s *ChangeRequestsService) List(ctx context.Context, opts ListOptions) ([]*ChangeRequest, *Response, error) {
-	u := fmt.Sprintf("/change_request.do")
-	var params []string
-	for _, v := range opts.QueryOpts {
-		params = append(params, fmt.Sprintf("%s%s%s", v.Key, v.Op, v.Val))
-	}
-
-	opts.internalFields.SysparmQuery = strings.Join(params[:], "+")
-	u, err := addOptions(u, opts)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var res struct {
-		ChangeRequests []*ChangeRequest `json:"records,omitempty"`
-	}
-	resp, err := s.client.Do(ctx, req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return res.ChangeRequests, resp, nil
-}
-
-// Get a single Change Request.
-func (s *ChangeRequestsService) Get(ctx context.Context, number string, opts GetOptions) (*ChangeRequest, *Response, error) {
-	u := fmt.Sprintf("/change_request.do")
-	if number == "" {
-		return nil, nil, fmt.Errorf("change number cannot be empty")
-	}
-	opts.internalFields.SysparmQuery = fmt.Sprintf("%s=%s", "number", number)
-	u, err := addOptions(u, opts)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var res struct {
-		ChangeRequests []*ChangeRequest `json:"records,omitempty"`
-	}
-	resp, err := s.client.Do(ctx, req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	chg := &ChangeRequest{}
-	if len(res.ChangeRequests) > 0 {
-		chg = res.ChangeRequests[0]
-	}
-
-	return chg, resp, nil
-}
-
-// Create a new change request on the specified CMDB CI.
-func (s *ChangeRequestsService) Create(ctx context.Context, chg *ChangeRequest, opts CreateOptions) (*ChangeRequest, *Response, error) {
-	u := fmt.Sprintf("/change_request.do")
-	opts.internalFields.SysparmAction = SysparmActionInsert
-	u, err := addOptions(u, opts)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("POST", u, chg)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var res struct {
-		ChangeRequests []*ChangeRequest `json:"records,omitempty"`
-	}
-	resp, err := s.client.Do(ctx, req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	resChg := &ChangeRequest{}
-	if len(res.ChangeRequests) > 0 {
-		resChg = res.ChangeRequests[0]
-	}
-
-	return resChg, resp, nil
-}
-
-// Update an existing change request on the specified CMDB CI.
-func (s *ChangeRequestsService) Update(ctx context.Context, number string, chg *ChangeRequest, opts UpdateOptions) (*ChangeRequest, *Response, error) {
-	u := fmt.Sprint("/change_request.do")
-	if number == "" {
-		return nil, nil, errors.New("change request number cannot be empty")
-	}
-	opts.internalFields.SysparmQuery = fmt.Sprintf("%s=%s", "number", number)
-	opts.internalFields.SysparmAction = SysparmActionUpdate
-	u, err := addOptions(u, opts)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("POST", u, chg)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var res struct {
-		ChangeRequests []*ChangeRequest `json:"records,omitempty"`
-	}
-	resp, err := s.client.Do(ctx, req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	resChg := &ChangeRequest{}
-	if len(res.ChangeRequests) > 0 {
-		resChg = res.ChangeRequests[0]
-	}
-
-	return resChg, resp, nil
-}
@@ -0,0 +1,382 @@
+package servicenow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// taskTable is the table that incidents, change requests and other tasks
+// extend, and which defines their common choice fields.
+const taskTable = "task"
+
+// IncidentState is the state of an incident.
+type IncidentState string
+
+const (
+	IncidentStateNew        IncidentState = "1"
+	IncidentStateInProgress IncidentState = "2"
+	IncidentStateOnHold     IncidentState = "3"
+	IncidentStateResolved   IncidentState = "6"
+	IncidentStateClosed     IncidentState = "7"
+	IncidentStateCanceled   IncidentState = "8"
+)
+
+// String returns the label of s in DefaultChoices, such as "Resolved", or s if
+// it is unknown. It ignores the choices set with WithChoices; use Label with
+// Client.Choices for those.
+func (s IncidentState) String() string {
+	return s.Label(DefaultChoices)
+}
+
+// Label returns the label of s in choices, or s if it is unknown.
+func (s IncidentState) Label(choices Choices) string {
+	return choices.Label(IncidentTable, "state", string(s))
+}
+
+// Parse sets s to the choice of choices whose label or value is label.
+func (s *IncidentState) Parse(choices Choices, label string) error {
+	v, err := choices.Parse(IncidentTable, "state", label)
+	if err != nil {
+		return err
+	}
+	*s = IncidentState(v)
+	return nil
+}
+
+// ParseIncidentState parses the label or value of an incident state in
+// DefaultChoices, such as "Resolved", "resolved" or "6". See
+// IncidentState.Parse for the choices of a client.
+func ParseIncidentState(s string) (IncidentState, error) {
+	var v IncidentState
+	err := v.Parse(DefaultChoices, s)
+	return v, err
+}
+
+// ChangeState is the state of a change request.
+type ChangeState string
+
+const (
+	ChangeStateNew       ChangeState = "-5"
+	ChangeStateAssess    ChangeState = "-4"
+	ChangeStateAuthorize ChangeState = "-3"
+	ChangeStateScheduled ChangeState = "-2"
+	ChangeStateImplement ChangeState = "-1"
+	ChangeStateReview    ChangeState = "0"
+	ChangeStateClosed    ChangeState = "3"
+	ChangeStateCanceled  ChangeState = "4"
+)
+
+// String returns the label of s in DefaultChoices, such as "Scheduled", or s if
+// it is unknown. It ignores the choices set with WithChoices; use Label with
+// Client.Choices for those.
+func (s ChangeState) String() string {
+	return s.Label(DefaultChoices)
+}
+
+// Label returns the label of s in choices, or s if it is unknown.
+func (s ChangeState) Label(choices Choices) string {
+	return choices.Label(ChangeRequestTable, "state", string(s))
+}
+
+// Parse sets s to the choice of choices whose label or value is label.
+func (s *ChangeState) Parse(choices Choices, label string) error {
+	v, err := choices.Parse(ChangeRequestTable, "state", label)
+	if err != nil {
+		return err
+	}
+	*s = ChangeState(v)
+	return nil
+}
+
+// ParseChangeState parses the label or value of a change request state in
+// DefaultChoices, such as "Scheduled" or "-2". See ChangeState.Parse for the
+// choices of a client.
+func ParseChangeState(s string) (ChangeState, error) {
+	var v ChangeState
+	err := v.Parse(DefaultChoices, s)
+	return v, err
+}
+
+// Priority is the priority of a task, derived from its impact and urgency.
+type Priority string
+
+const (
+	PriorityCritical Priority = "1"
+	PriorityHigh     Priority = "2"
+	PriorityModerate Priority = "3"
+	PriorityLow      Priority = "4"
+	PriorityPlanning Priority = "5"
+)
+
+// String returns the label of p in DefaultChoices, such as "Critical", or p if
+// it is unknown. It ignores the choices set with WithChoices; use Label with
+// Client.Choices for those.
+func (p Priority) String() string {
+	return p.Label(DefaultChoices)
+}
+
+// Label returns the label of p in choices, or p if it is unknown.
+func (p Priority) Label(choices Choices) string {
+	return choices.Label(taskTable, "priority", string(p))
+}
+
+// Parse sets p to the choice of choices whose label or value is label.
+func (p *Priority) Parse(choices Choices, label string) error {
+	v, err := choices.Parse(taskTable, "priority", label)
+	if err != nil {
+		return err
+	}
+	*p = Priority(v)
+	return nil
+}
+
+// ParsePriority parses the label or value of a priority in DefaultChoices,
+// such as "Critical" or "1". See Priority.Parse for the choices of a client.
+func ParsePriority(s string) (Priority, error) {
+	var v Priority
+	err := v.Parse(DefaultChoices, s)
+	return v, err
+}
+
+// Impact is the impact of a task.
+type Impact string
+
+const (
+	ImpactHigh   Impact = "1"
+	ImpactMedium Impact = "2"
+	ImpactLow    Impact = "3"
+)
+
+// String returns the label of i in DefaultChoices, such as "High", or i if it
+// is unknown. It ignores the choices set with WithChoices; use Label with
+// Client.Choices for those.
+func (i Impact) String() string {
+	return i.Label(DefaultChoices)
+}
+
+// Label returns the label of i in choices, or i if it is unknown.
+func (i Impact) Label(choices Choices) string {
+	return choices.Label(taskTable, "impact", string(i))
+}
+
+// Parse sets i to the choice of choices whose label or value is label.
+func (i *Impact) Parse(choices Choices, label string) error {
+	v, err := choices.Parse(taskTable, "impact", label)
+	if err != nil {
+		return err
+	}
+	*i = Impact(v)
+	return nil
+}
+
+// ParseImpact parses the label or value of an impact in DefaultChoices,
+// such as "High" or "1". See Impact.Parse for the choices of a client.
+func ParseImpact(s string) (Impact, error) {
+	var v Impact
+	err := v.Parse(DefaultChoices, s)
+	return v, err
+}
+
+// Urgency is the urgency of a task.
+type Urgency string
+
+const (
+	UrgencyHigh   Urgency = "1"
+	UrgencyMedium Urgency = "2"
+	UrgencyLow    Urgency = "3"
+)
+
+// String returns the label of u in DefaultChoices, such as "High", or u if it
+// is unknown. It ignores the choices set with WithChoices; use Label with
+// Client.Choices for those.
+func (u Urgency) String() string {
+	return u.Label(DefaultChoices)
+}
+
+// Label returns the label of u in choices, or u if it is unknown.
+func (u Urgency) Label(choices Choices) string {
+	return choices.Label(taskTable, "urgency", string(u))
+}
+
+// Parse sets u to the choice of choices whose label or value is label.
+func (u *Urgency) Parse(choices Choices, label string) error {
+	v, err := choices.Parse(taskTable, "urgency", label)
+	if err != nil {
+		return err
+	}
+	*u = Urgency(v)
+	return nil
+}
+
+// ParseUrgency parses the label or value of an urgency in DefaultChoices,
+// such as "High" or "1". See Urgency.Parse for the choices of a client.
+func ParseUrgency(s string) (Urgency, error) {
+	var v Urgency
+	err := v.Parse(DefaultChoices, s)
+	return v, err
+}
+
+// ChoiceSet maps the values of a choice field to their labels.
+type ChoiceSet map[string]string
+
+// Choices holds the choice sets of fields, keyed by table and field name
+// joined by a dot, such as "incident.state". Choice sets of the task table
+// apply to the tables that extend it, such as incident and change_request,
+// unless those tables define their own.
+type Choices map[string]ChoiceSet
+
+// DefaultChoices are the out of the box choices of the instance. They are
+// used by the String methods and Parse functions of the choice types, such as
+// IncidentState.String and ParseIncidentState, and by clients that have not
+// been given other choices with WithChoices.
+//
+// For an instance with custom choices, use the Label and Parse methods of the
+// choice types with the choices returned by Client.Choices instead:
+//
+//	choices := client.Choices()
+//	label := inc.State.Get().Label(choices)
+//	var state servicenow.IncidentState
+//	err := state.Parse(choices, "Awaiting Vendor")
+var DefaultChoices = Choices{
+	IncidentTable + ".state": {
+		"1": "New",
+		"2": "In Progress",
+		"3": "On Hold",
+		"6": "Resolved",
+		"7": "Closed",
+		"8": "Canceled",
+	},
+	IncidentTable + ".incident_state": {
+		"1": "New",
+		"2": "In Progress",
+		"3": "On Hold",
+		"6": "Resolved",
+		"7": "Closed",
+		"8": "Canceled",
+	},
+	ChangeRequestTable + ".state": {
+		"-5": "New",
+		"-4": "Assess",
+		"-3": "Authorize",
+		"-2": "Scheduled",
+		"-1": "Implement",
+		"0":  "Review",
+		"3":  "Closed",
+		"4":  "Canceled",
+	},
+	taskTable + ".priority": {
+		"1": "Critical",
+		"2": "High",
+		"3": "Moderate",
+		"4": "Low",
+		"5": "Planning",
+	},
+	taskTable + ".impact": {
+		"1": "High",
+		"2": "Medium",
+		"3": "Low",
+	},
+	taskTable + ".urgency": {
+		"1": "High",
+		"2": "Medium",
+		"3": "Low",
+	},
+}
+
+// Set returns the choice set of field in table, falling back to the choice
+// set of field in the task table, or nil if there is none.
+func (c Choices) Set(table, field string) ChoiceSet {
+	if set, ok := c[table+"."+field]; ok {
+		return set
+	}
+	return c[taskTable+"."+field]
+}
+
+// Label returns the label of value for field in table, or value if it has no
+// label.
+func (c Choices) Label(table, field, value string) string {
+	if label, ok := c.Set(table, field)[value]; ok {
+		return label
+	}
+	return value
+}
+
+// Parse returns the value of the choice of field in table whose label or
+// value is s. Labels are matched regardless of case, and spaces, hyphens and
+// underscores are interchangeable, so that "in progress" and "IN_PROGRESS"
+// both match "In Progress".
+func (c Choices) Parse(table, field, s string) (string, error) {
+	set := c.Set(table, field)
+	if _, ok := set[s]; ok {
+		return s, nil
+	}
+	symbol := choiceSymbol(s)
+	for value, label := range set {
+		if choiceSymbol(label) == symbol {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("unknown choice %q for %s.%s", s, table, field)
+}
+
+// FilterSymbols returns the symbols of the choice fields of table, for use
+// with a FilterCompiler.
+func (c Choices) FilterSymbols(table string) FilterSymbols {
+	symbols := FilterSymbols{}
+	add := func(key string, set ChoiceSet) {
+		field := key[strings.IndexByte(key, '.')+1:]
+		symbols[field] = map[string]string{}
+		for value, label := range set {
+			symbols[field][choiceSymbol(label)] = value
+		}
+	}
+	// Choices of table take precedence over those of the task table.
+	for key, set := range c {
+		if strings.HasPrefix(key, taskTable+".") {
+			add(key, set)
+		}
+	}
+	for key, set := range c {
+		if strings.HasPrefix(key, table+".") {
+			add(key, set)
+		}
+	}
+	return symbols
+}
+
+// choiceSymbol returns the lower case form of label with spaces and hyphens
+// replaced by underscores, such as in_progress for "In Progress".
+func choiceSymbol(label string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(label)))
+}
+
+// WithChoices returns a ClientOption that sets the choices of the instance,
+// for instances with custom choice values. Choice sets in choices replace the
+// corresponding sets of DefaultChoices.
+func WithChoices(choices Choices) ClientOption {
+	return func(c *Client) {
+		c.choices = choices
+	}
+}
+
+// Choices returns the choices of the instance: DefaultChoices, with the sets
+// given with WithChoices replacing the corresponding default sets. Pass it
+// to the Label and Parse methods of the choice types, such as
+// IncidentState.Label, which are the per-instance counterparts of their
+// String methods and Parse functions.
+func (c *Client) Choices() Choices {
+	choices := Choices{}
+	for key, set := range DefaultChoices {
+		choices[key] = set
+	}
+	for key, set := range c.choices {
+		choices[key] = set
+	}
+	return choices
+}
+
+// FilterCompiler returns a FilterCompiler that uses the symbols of the choice
+// fields of table, as returned by Choices.
+func (c *Client) FilterCompiler(table string) *FilterCompiler {
+	return &FilterCompiler{Symbols: c.Choices().FilterSymbols(table)}
+}
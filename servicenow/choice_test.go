@@ -0,0 +1,88 @@
+package servicenow
+
+import "testing"
+
+func TestClient_Choices(t *testing.T) {
+	client, _ := setup(t, WithChoices(Choices{
+		IncidentTable + ".state": {"1": "New", "18": "Awaiting Vendor"},
+		taskTable + ".priority":  {"1": "P1", "2": "P2"},
+	}))
+	choices := client.Choices()
+
+	labels := []struct {
+		table, field, value, want string
+	}{
+		{IncidentTable, "state", "18", "Awaiting Vendor"},
+		{IncidentTable, "state", "6", "6"}, // replaced set
+		{IncidentTable, "priority", "1", "P1"},
+		{ChangeRequestTable, "priority", "2", "P2"},
+		{ChangeRequestTable, "state", "-2", "Scheduled"}, // default set
+	}
+	for _, tt := range labels {
+		if got := choices.Label(tt.table, tt.field, tt.value); got != tt.want {
+			t.Errorf("Label(%s, %s, %s) = %q, want %q", tt.table, tt.field, tt.value, got, tt.want)
+		}
+	}
+	if v, err := choices.Parse(IncidentTable, "state", "awaiting_vendor"); err != nil || v != "18" {
+		t.Errorf("Parse = %q, %v, want 18", v, err)
+	}
+	if _, err := choices.Parse(IncidentTable, "state", "Resolved"); err == nil {
+		t.Errorf("Parse of a replaced choice returned no error")
+	}
+
+	// The String methods and Parse functions of the choice types use
+	// DefaultChoices, and their Label and Parse methods the given choices.
+	if got := IncidentState("6").String(); got != "Resolved" {
+		t.Errorf("IncidentState.String = %q, want Resolved", got)
+	}
+	if got := IncidentState("18").String(); got != "18" {
+		t.Errorf("IncidentState.String = %q, want 18", got)
+	}
+	if got := IncidentState("18").Label(choices); got != "Awaiting Vendor" {
+		t.Errorf("IncidentState.Label = %q, want Awaiting Vendor", got)
+	}
+	if got := PriorityCritical.Label(choices); got != "P1" {
+		t.Errorf("Priority.Label = %q, want P1", got)
+	}
+	if got := ChangeStateScheduled.Label(choices); got != "Scheduled" {
+		t.Errorf("ChangeState.Label = %q, want Scheduled", got)
+	}
+	if got := ImpactLow.Label(choices) + "," + UrgencyLow.Label(choices); got != "Low,Low" {
+		t.Errorf("Impact.Label and Urgency.Label = %q, want Low,Low", got)
+	}
+	var state IncidentState
+	if err := state.Parse(choices, "Awaiting Vendor"); err != nil || state != "18" {
+		t.Errorf("IncidentState.Parse = %q, %v, want 18", state, err)
+	}
+	if err := state.Parse(choices, "Resolved"); err == nil || state != "18" {
+		t.Errorf("IncidentState.Parse of an unknown label = %q, %v, want an error and no change", state, err)
+	}
+	if _, err := ParseIncidentState("Awaiting Vendor"); err == nil {
+		t.Errorf("ParseIncidentState of a client choice returned no error")
+	}
+	var priority Priority
+	if err := priority.Parse(choices, "p2"); err != nil || priority != PriorityHigh {
+		t.Errorf("Priority.Parse = %q, %v, want 2", priority, err)
+	}
+	if _, ok := DefaultChoices[IncidentTable+".state"]["18"]; ok {
+		t.Errorf("WithChoices modified DefaultChoices")
+	}
+}
+
+func TestDefaultFilterSymbols(t *testing.T) {
+	const key = IncidentTable + ".state"
+	saved := DefaultChoices[key]
+	defer func() { DefaultChoices[key] = saved }()
+
+	DefaultChoices[key] = ChoiceSet{"1": "New", "18": "Awaiting Vendor"}
+	q, err := CompileFilter("state = awaiting_vendor")
+	if err != nil {
+		t.Fatalf("CompileFilter returned error: %v", err)
+	}
+	if got := q.String(); got != "state=18" {
+		t.Errorf("CompileFilter = %q, want the symbols of the modified DefaultChoices", got)
+	}
+	if got := DefaultFilterSymbols()["state"]["awaiting_vendor"]; got != "18" {
+		t.Errorf("DefaultFilterSymbols state awaiting_vendor = %q, want 18", got)
+	}
+}
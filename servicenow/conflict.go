@@ -0,0 +1,147 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultConflictAttempts is the number of attempts RetryOnConflict makes.
+const defaultConflictAttempts = 5
+
+// ErrConflict is wrapped by the errors returned when a record was modified
+// by someone else since it was read.
+var ErrConflict = errors.New("record was modified since it was read")
+
+// ConflictError is returned by UpdateIfUnchanged when the record was modified
+// since it was read. It holds both versions of the record, so that the caller
+// can merge them or report the conflict.
+type ConflictError[T any] struct {
+	Table     string
+	SysID     string
+	Attempted *T // Record the caller attempted to write
+	Current   *T // Record as currently stored by the instance
+}
+
+func (e *ConflictError[T]) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Table, e.SysID, ErrConflict)
+}
+
+// Is reports whether target is ErrConflict, so that conflicts can be detected
+// with errors.Is regardless of T.
+func (e *ConflictError[T]) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// IsConflict reports whether err is a ConflictError, or wraps ErrConflict.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// UpdateIfUnchanged updates record, identified by its sys_id, only if it has
+// not been modified since it was read. The version of record is given by its
+// sys_mod_count or, if that is unset, its sys_updated_on field, as read from
+// the instance. If the stored record has another version, the update is not
+// made and a *ConflictError[T] holding both versions is returned.
+//
+// The instance compares sys_updated_on in the time zone of the user, so set
+// it with WithLocation if it is not UTC, or read sys_mod_count.
+//
+// The JSONv2 processor only updates the record if it still has the version of
+// record, in a single request. The Table API cannot update records
+// conditionally, so the version is checked by a request made just before the
+// update; a write made between the two requests is not detected.
+func (s *TableService[T]) UpdateIfUnchanged(ctx context.Context, record *T, opts UpdateOptions) (*T, *Response, error) {
+	fields, err := recordFields(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	sysID := lookupField(fields, "sys_id")
+	if sysID == "" {
+		return nil, nil, fmt.Errorf("%s sys_id cannot be empty", s.table)
+	}
+
+	q := NewQuery().Eq("sys_id", sysID)
+	if modCount := lookupField(fields, "sys_mod_count"); modCount != "" {
+		q.Eq("sys_mod_count", modCount)
+	} else if updatedOn := lookupField(fields, "sys_updated_on"); updatedOn != "" {
+		// Records encode times in UTC, but the instance evaluates encoded
+		// queries in the time zone of the user.
+		if s.client.location != nil {
+			t, err := time.Parse(timestampLayout, updatedOn)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s %s: invalid sys_updated_on %q: %v", s.table, sysID, updatedOn, err)
+			}
+			updatedOn = t.In(s.client.location).Format(queryTimeLayout)
+		}
+		q.Eq("sys_updated_on", updatedOn)
+	} else {
+		return nil, nil, fmt.Errorf("%s %s: record has no sys_mod_count or sys_updated_on", s.table, sysID)
+	}
+	if opts.internalFields.SysparmQuery, err = q.Encode(); err != nil {
+		return nil, nil, err
+	}
+	body, err := encodeRecord(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*T
+	resp, err := s.client.updateRecords(ctx, s.table, body, opts, &records)
+	if err != nil && !IsNotFound(err) {
+		return nil, resp, err
+	}
+	if err == nil && len(records) > 0 {
+		return records[0], resp, nil
+	}
+
+	// No record had both the sys_id and the version of record: it was either
+	// modified or deleted.
+	current, resp, err := s.getRecords(ctx, BySysID(sysID), GetOptions{})
+	if IsNotFound(err) || (err == nil && len(current) == 0) {
+		return nil, resp, fmt.Errorf("%s %s: %w", s.table, sysID, ErrRecordNotFound)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	return nil, resp, &ConflictError[T]{Table: s.table, SysID: sysID, Attempted: record, Current: current[0]}
+}
+
+// RetryOnConflict reads the record ref refers to, applies mutate to it and
+// writes it back with UpdateIfUnchanged. If the record was modified by
+// someone else in the meantime, it is read again and mutate is applied to the
+// new version, up to 5 times in all. The error returned by mutate, if any,
+// is returned as is, without updating the record.
+//
+//	inc, _, err := client.Incidents.RetryOnConflict(ctx, servicenow.ByNumber("INC0010001"),
+//		func(inc *servicenow.Incident) error {
+//			inc.State = servicenow.NewField(servicenow.IncidentStateInProgress)
+//			return nil
+//		}, servicenow.UpdateOptions{})
+func (s *TableService[T]) RetryOnConflict(ctx context.Context, ref RecordRef, mutate func(record *T) error, opts UpdateOptions) (*T, *Response, error) {
+	var (
+		resp *Response
+		err  error
+	)
+	for attempt := 0; attempt < defaultConflictAttempts; attempt++ {
+		var records []*T
+		records, resp, err = s.getRecords(ctx, ref, GetOptions{})
+		if IsNotFound(err) || (err == nil && len(records) == 0) {
+			return nil, resp, fmt.Errorf("%s %s: %w", s.table, ref, ErrRecordNotFound)
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+		record := records[0]
+		if err := mutate(record); err != nil {
+			return nil, resp, err
+		}
+
+		record, resp, err = s.UpdateIfUnchanged(ctx, record, opts)
+		if !IsConflict(err) {
+			return record, resp, err
+		}
+	}
+	return nil, resp, err
+}
@@ -0,0 +1,110 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// conflictHandler serves a table in which no record matches the version
+// condition of UpdateIfUnchanged, and record s1 has the given fields, unless
+// current is nil, in which case it was deleted.
+func conflictHandler(t *testing.T, backend Backend, current map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var recs []map[string]interface{}
+		switch {
+		case r.Method == "GET" && r.URL.Query().Get("sysparm_fields") == "sys_id":
+			// sys_id lookup made by Update on the Table API.
+		case r.Method == "GET" && r.URL.Query().Get("sysparm_action") != "update":
+			if current == nil {
+				if backend == BackendTable {
+					w.WriteHeader(http.StatusNotFound)
+					writeJSON(t, w, map[string]interface{}{"error": map[string]string{"message": "No Record found"}, "status": "failure"})
+					return
+				}
+				break
+			}
+			recs = append(recs, current)
+		}
+		if backend == BackendTable {
+			writeJSON(t, w, result(recs))
+		} else {
+			writeJSON(t, w, records(recs...))
+		}
+	}
+}
+
+func TestTableService_UpdateIfUnchanged_deleted(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		h := conflictHandler(t, backend, nil)
+		mux.HandleFunc("/incident.do", h)
+		mux.HandleFunc("/api/now/table/incident", h)
+		mux.HandleFunc("/api/now/table/incident/s1", h)
+
+		inc := &Incident{SysID: NewField("s1"), SysModCount: NewField("3")}
+		_, _, err := client.Incidents.UpdateIfUnchanged(context.Background(), inc, UpdateOptions{})
+		if !errors.Is(err, ErrRecordNotFound) || IsConflict(err) {
+			t.Errorf("backend %v: UpdateIfUnchanged returned error %v, want ErrRecordNotFound", backend, err)
+		}
+
+		calls := 0
+		_, _, err = client.Incidents.RetryOnConflict(context.Background(), BySysID("s1"), func(*Incident) error {
+			calls++
+			return nil
+		}, UpdateOptions{})
+		if !errors.Is(err, ErrRecordNotFound) || calls != 0 {
+			t.Errorf("backend %v: RetryOnConflict returned error %v after %d calls, want ErrRecordNotFound before mutate", backend, err, calls)
+		}
+	}
+}
+
+func TestTableService_UpdateIfUnchanged_conflict(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		h := conflictHandler(t, backend, map[string]interface{}{"sys_id": "s1", "sys_mod_count": "4"})
+		mux.HandleFunc("/incident.do", h)
+		mux.HandleFunc("/api/now/table/incident", h)
+		mux.HandleFunc("/api/now/table/incident/s1", h)
+
+		inc := &Incident{SysID: NewField("s1"), SysModCount: NewField("3")}
+		_, _, err := client.Incidents.UpdateIfUnchanged(context.Background(), inc, UpdateOptions{})
+		var e *ConflictError[Incident]
+		if !errors.As(err, &e) {
+			t.Fatalf("backend %v: UpdateIfUnchanged returned error %v, want *ConflictError", backend, err)
+		}
+		if e.Current.GetSysModCount() != "4" || e.Attempted != inc {
+			t.Errorf("backend %v: ConflictError = %+v", backend, e)
+		}
+	}
+}
+
+func TestTableService_UpdateIfUnchanged_updatedOn(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	updatedOn := Timestamp{time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want string
+	}{
+		{"utc", nil, "sys_id=s1^sys_updated_on=2024-01-02 10:00:00"},
+		{"location", []ClientOption{WithLocation(loc)}, "sys_id=s1^sys_updated_on=2024-01-02 05:00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t, tt.opts...)
+			mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				testQuery(t, r, tt.want)
+				writeJSON(t, w, records(map[string]interface{}{"sys_id": "s1"}))
+			})
+
+			inc := &Incident{SysID: NewField("s1"), SysUpdatedOn: NewField(updatedOn)}
+			if _, _, err := client.Incidents.UpdateIfUnchanged(context.Background(), inc, UpdateOptions{}); err != nil {
+				t.Errorf("UpdateIfUnchanged returned error: %v", err)
+			}
+		})
+	}
+}
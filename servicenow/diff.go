@@ -0,0 +1,112 @@
+package servicenow
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Change is the change of a single field between two versions of a record.
+type Change struct {
+	Field string          // Field name
+	Old   json.RawMessage // Encoded old value, or nil if the field was unset
+	New   json.RawMessage // Encoded new value
+}
+
+// Changes are the changes between two versions of a record, ordered by field
+// name.
+type Changes []Change
+
+// Diff returns the fields of new that differ from those of old, which are
+// typically a record as it was read and the same record after the caller
+// modified it. Records are compared as encoded by MarshalRecord, so that the
+// fields in Extra are compared too. Fields that are unset in new are left
+// alone by an update and are not reported as changed, even if they are set in
+// old; clear a field by setting it to a null Field instead.
+//
+// Send only the changes with UpdateFields, so that fields changed by others
+// since the record was read are not overwritten:
+//
+//	changes, err := servicenow.Diff(old, inc)
+//	if err != nil {
+//		return err
+//	}
+//	if len(changes) > 0 {
+//		log.Printf("updating %s: %s", inc.GetNumber(), changes)
+//		_, _, err = client.Incidents.UpdateFields(ctx, inc.GetNumber(), changes.Patch(), servicenow.UpdateOptions{})
+//	}
+func Diff[T any](old, new *T) (Changes, error) {
+	oldFields, err := encodedFields(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := encodedFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes Changes
+	for field, value := range newFields {
+		if oldValue, ok := oldFields[field]; ok && bytes.Equal(oldValue, value) {
+			continue
+		}
+		changes = append(changes, Change{Field: field, Old: oldFields[field], New: value})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// encodedFields returns the fields of record as encoded by MarshalRecord, in
+// compact form so that equal values are byte-equal.
+func encodedFields(record interface{}) (map[string]json.RawMessage, error) {
+	b, err := MarshalRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for field, value := range fields {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, value); err != nil {
+			return nil, err
+		}
+		fields[field] = buf.Bytes()
+	}
+	return fields, nil
+}
+
+// Patch returns the new values of the changed fields, for use with
+// UpdateFields.
+func (c Changes) Patch() map[string]interface{} {
+	patch := make(map[string]interface{}, len(c))
+	for _, change := range c {
+		patch[change.Field] = change.New
+	}
+	return patch
+}
+
+// String returns the changes in the form
+// servicenow.Changes{assigned_to:"<sys_id>" -> "", state:"2" -> "6"}, for
+// audit logs.
+func (c Changes) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("servicenow.Changes{")
+	for i, change := range c {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(change.Field)
+		buf.WriteByte(':')
+		if change.Old == nil {
+			buf.WriteString("<nil>")
+		} else {
+			buf.Write(change.Old)
+		}
+		buf.WriteString(" -> ")
+		buf.Write(change.New)
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
@@ -0,0 +1,158 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new *teamIncident
+		want     Changes
+	}{
+		{
+			name: "equal",
+			old:  &teamIncident{Incident: Incident{Number: NewField("INC1")}, Team: NewField("sre")},
+			new:  &teamIncident{Incident: Incident{Number: NewField("INC1")}, Team: NewField("sre")},
+		},
+		{
+			name: "set",
+			old:  &teamIncident{Incident: Incident{State: NewField(IncidentStateNew)}},
+			new:  &teamIncident{Incident: Incident{State: NewField(IncidentStateResolved)}},
+			want: Changes{{Field: "state", Old: json.RawMessage(`"1"`), New: json.RawMessage(`"6"`)}},
+		},
+		{
+			name: "set from unset",
+			old:  &teamIncident{},
+			new:  &teamIncident{Incident: Incident{Description: NewField("d")}},
+			want: Changes{{Field: "description", New: json.RawMessage(`"d"`)}},
+		},
+		{
+			name: "null",
+			old:  &teamIncident{Incident: Incident{AssignedTo: NewField(*NewReference("u1"))}},
+			new:  &teamIncident{Incident: Incident{AssignedTo: NullField[Reference]()}},
+			want: Changes{{Field: "assigned_to", Old: json.RawMessage(`"u1"`), New: json.RawMessage(`""`)}},
+		},
+		{
+			// Unset fields are left alone by an update.
+			name: "unset",
+			old:  &teamIncident{Incident: Incident{Description: NewField("d")}},
+			new:  &teamIncident{},
+		},
+		{
+			name: "embedding struct",
+			old:  &teamIncident{Team: NewField("sre")},
+			new:  &teamIncident{Team: NewField("ops")},
+			want: Changes{{Field: "u_team", Old: json.RawMessage(`"sre"`), New: json.RawMessage(`"ops"`)}},
+		},
+		{
+			name: "extra",
+			old:  &teamIncident{Incident: Incident{Extra: map[string]json.RawMessage{"u_tier": json.RawMessage(`"1"`), "u_other": json.RawMessage(`{"a": 1}`)}}},
+			new:  &teamIncident{Incident: Incident{Extra: map[string]json.RawMessage{"u_tier": json.RawMessage(`"2"`), "u_other": json.RawMessage(`{"a":1}`)}}},
+			want: Changes{{Field: "u_tier", Old: json.RawMessage(`"1"`), New: json.RawMessage(`"2"`)}},
+		},
+		{
+			name: "ordered by field",
+			old:  &teamIncident{},
+			new:  &teamIncident{Incident: Incident{State: NewField(IncidentStateNew), Description: NewField("d")}, Team: NewField("sre")},
+			want: Changes{
+				{Field: "description", New: json.RawMessage(`"d"`)},
+				{Field: "state", New: json.RawMessage(`"1"`)},
+				{Field: "u_team", New: json.RawMessage(`"sre"`)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.old, tt.new)
+			if err != nil {
+				t.Fatalf("Diff returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanges_Patch(t *testing.T) {
+	tests := []struct {
+		changes Changes
+		want    map[string]interface{}
+	}{
+		{nil, map[string]interface{}{}},
+		{
+			Changes{
+				{Field: "assigned_to", Old: json.RawMessage(`"u1"`), New: json.RawMessage(`""`)},
+				{Field: "state", New: json.RawMessage(`"6"`)},
+			},
+			map[string]interface{}{"assigned_to": json.RawMessage(`""`), "state": json.RawMessage(`"6"`)},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.changes.Patch(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Patch(%v) = %v, want %v", tt.changes, got, tt.want)
+		}
+	}
+}
+
+func TestChanges_String(t *testing.T) {
+	tests := []struct {
+		changes Changes
+		want    string
+	}{
+		{nil, "servicenow.Changes{}"},
+		{
+			Changes{
+				{Field: "assigned_to", Old: json.RawMessage(`"u1"`), New: json.RawMessage(`""`)},
+				{Field: "state", New: json.RawMessage(`"6"`)},
+			},
+			`servicenow.Changes{assigned_to:"u1" -> "", state:<nil> -> "6"}`,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.changes.String(); got != tt.want {
+			t.Errorf("String = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestTableService_UpdateFields_diff(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testQuery(t, r, "number=INC1")
+		if got := r.URL.Query().Get("sysparm_action"); got != "update" {
+			t.Errorf("sysparm_action = %q, want update", got)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading body: %v", err)
+		}
+		if got, want := string(body), `{"assigned_to":"","state":"6","u_team":"ops"}`+"\n"; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		writeJSON(t, w, records(map[string]interface{}{"number": "INC1", "state": "6"}))
+	})
+
+	old := &teamIncident{
+		Incident: Incident{Number: NewField("INC1"), State: NewField(IncidentStateNew), AssignedTo: NewField(*NewReference("u1"))},
+		Team:     NewField("sre"),
+	}
+	inc := &teamIncident{
+		Incident: Incident{Number: NewField("INC1"), State: NewField(IncidentStateResolved), AssignedTo: NullField[Reference]()},
+		Team:     NewField("ops"),
+	}
+	changes, err := Diff(old, inc)
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if _, _, err := client.Incidents.UpdateFields(context.Background(), "INC1", changes.Patch(), UpdateOptions{}); err != nil {
+		t.Errorf("UpdateFields returned error: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package servicenow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Field is the value of a record field, which is unset, null or set to a
+// value.
+//
+// Record structs hold *Field values. A nil *Field is unset: it is omitted when
+// the record is encoded, so that Update leaves the field alone. A null Field,
+// as returned by NullField, is encoded as an empty string, which clears the
+// field. The instance returns empty fields as empty strings, which are decoded
+// as null Fields.
+type Field[T any] struct {
+	value T
+	valid bool
+}
+
+// NewField returns a Field set to v.
+func NewField[T any](v T) *Field[T] {
+	return &Field[T]{value: v, valid: true}
+}
+
+// NullField returns a null Field, which clears the field when the record is
+// created or updated.
+func NullField[T any]() *Field[T] {
+	return &Field[T]{}
+}
+
+// IsSet reports whether f is set, either to a value or to null.
+func (f *Field[T]) IsSet() bool {
+	return f != nil
+}
+
+// IsNull reports whether f is null.
+func (f *Field[T]) IsNull() bool {
+	return f != nil && !f.valid
+}
+
+// Get returns the value of f, or the zero value of T if f is unset or null.
+func (f *Field[T]) Get() T {
+	v, _ := f.Value()
+	return v
+}
+
+// Value returns the value of f and reports whether f holds one, that is
+// whether it is neither unset nor null.
+func (f *Field[T]) Value() (T, bool) {
+	if f == nil || !f.valid {
+		var zero T
+		return zero, false
+	}
+	return f.value, true
+}
+
+func (f Field[T]) String() string {
+	if !f.valid {
+		return "null"
+	}
+	return fmt.Sprint(f.value)
+}
+
+// MarshalJSON implements the json.Marshaler interface. A null Field is
+// encoded as an empty string.
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.valid {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(f.value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. An empty string
+// or null is decoded as a null Field.
+//
+// With DisplayValue "all", the Table API returns each field as an object
+// holding its value and display value. If T cannot be decoded from such an
+// object, as a string cannot, the Field is decoded from its value member and
+// the display value is dropped; use a Field[DisplayValuePair] to keep both.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch string(data) {
+	case `""`, "null":
+		*f = Field[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		if len(data) == 0 || data[0] != '{' {
+			return err
+		}
+		var pair struct {
+			Value json.RawMessage `json:"value"`
+		}
+		if json.Unmarshal(data, &pair) != nil || len(pair.Value) == 0 || pair.Value[0] == '{' {
+			return err
+		}
+		return f.UnmarshalJSON(pair.Value)
+	}
+	*f = Field[T]{value: v, valid: true}
+	return nil
+}
+
+// fieldValue returns the value of f and whether it holds one, for Stringify.
+func (f Field[T]) fieldValue() (interface{}, bool) {
+	return f.value, f.valid
+}
+
+// fromDisplayValue moves the display value decoded into the value of f, if
+// it holds a Reference or DisplayValuePair, to its DisplayValue.
+func (f *Field[T]) fromDisplayValue() {
+	if d, ok := interface{}(&f.value).(displayValuer); ok && f.valid {
+		d.fromDisplayValue()
+	}
+}
+
+// localize localizes the value of f if it holds display values of date and
+// time fields.
+func (f *Field[T]) localize(loc *time.Location) {
+	if l, ok := interface{}(&f.value).(localizer); ok && f.valid {
+		l.localize(loc)
+	}
+}
@@ -0,0 +1,164 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestField_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want *Field[string]
+	}{
+		{`"x"`, NewField("x")},
+		{`""`, NullField[string]()},
+		{`null`, NullField[string]()},
+		{`{"value":"x","display_value":"X"}`, NewField("x")},
+		{`{"value":"","display_value":""}`, NullField[string]()},
+	}
+	for _, tt := range tests {
+		got := new(Field[string])
+		if err := json.Unmarshal([]byte(tt.data), got); err != nil {
+			t.Errorf("Unmarshal(%s) returned error: %v", tt.data, err)
+			continue
+		}
+		if *got != *tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+
+	for _, data := range []string{`{"display_value":"X"}`, `{"value":{"value":"x"}}`, `[1]`} {
+		if err := json.Unmarshal([]byte(data), new(Field[string])); err == nil {
+			t.Errorf("Unmarshal(%s) returned no error", data)
+		}
+	}
+}
+
+func TestField_MarshalJSON(t *testing.T) {
+	type record struct {
+		Name     *Field[string]    `json:"name,omitempty"`
+		Caller   *Field[Reference] `json:"caller,omitempty"`
+		OpenedAt *Field[Timestamp] `json:"opened_at,omitempty"`
+	}
+	openedAt := Timestamp{time.Date(2024, 1, 2, 4, 4, 5, 0, time.FixedZone("UTC+1", 60*60))}
+	tests := []struct {
+		name   string
+		record record
+		want   string
+	}{
+		{"unset", record{}, `{}`},
+		{"null", record{NullField[string](), NullField[Reference](), NullField[Timestamp]()}, `{"name":"","caller":"","opened_at":""}`},
+		{"set", record{NewField("x"), NewField(*NewReference("u1")), NewField(openedAt)}, `{"name":"x","caller":"u1","opened_at":"2024-01-02 03:04:05"}`},
+		{"empty", record{NewField(""), NewField(Reference{}), NewField(Timestamp{})}, `{"name":"","caller":"","opened_at":""}`},
+		{"display value", record{Caller: NewField(Reference{DisplayValue: stringPtr("Abel Tuter"), Link: stringPtr("https://x/u1")})}, `{"caller":"Abel Tuter"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.record)
+			if err != nil {
+				t.Fatalf("Marshal returned error: %v", err)
+			}
+			if got := string(b); got != tt.want {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableService_List_displayValueAll(t *testing.T) {
+	client, mux := setup(t, WithBackend(BackendTable))
+	mux.HandleFunc("/api/now/table/incident", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sysparm_display_value"); got != "all" {
+			t.Errorf("sysparm_display_value = %q, want all", got)
+		}
+		writeJSON(t, w, result([]map[string]interface{}{{
+			"number":           map[string]string{"value": "INC1", "display_value": "INC1"},
+			"priority":         map[string]string{"value": "2", "display_value": "2 - High"},
+			"opened_at":        map[string]string{"value": "2024-01-02 03:04:05", "display_value": "2024-01-02 04:04:05"},
+			"assignment_group": map[string]string{"value": "g1", "display_value": "SRE", "link": "https://x/g1"},
+			"description":      map[string]string{"value": "", "display_value": ""},
+		}}))
+	})
+
+	incs, _, err := client.Incidents.List(context.Background(), ListOptions{DisplayValue: DisplayValueAll})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(incs) != 1 {
+		t.Fatalf("List returned %d records, want 1", len(incs))
+	}
+	inc := incs[0]
+	if got := inc.GetNumber(); got != "INC1" {
+		t.Errorf("Number = %q, want INC1", got)
+	}
+	if got := inc.Priority.Get(); got != PriorityHigh {
+		t.Errorf("Priority = %q, want %q", got, PriorityHigh)
+	}
+	if got := inc.OpenedAt.Get().Hour(); got != 3 {
+		t.Errorf("OpenedAt hour = %d, want the value, 3", got)
+	}
+	if ref := inc.AssignmentGroup.Get(); ref.GetValue() != "g1" || ref.GetDisplayValue() != "SRE" {
+		t.Errorf("AssignmentGroup = %v, want g1 (SRE)", ref)
+	}
+	if !inc.Description.IsNull() {
+		t.Errorf("Description = %v, want null", inc.Description)
+	}
+}
+
+func TestTableService_List_displayValueTrue(t *testing.T) {
+	tests := []struct {
+		backend    Backend
+		display    DisplayValueType
+		assignedTo interface{}
+		want       Reference
+	}{
+		{BackendJSONv2, DisplayValueTrue, "Beth Anglin", Reference{DisplayValue: stringPtr("Beth Anglin")}},
+		{BackendJSONv2, DisplayValueFalse, "u1", Reference{Value: stringPtr("u1")}},
+		{BackendJSONv2, "", "u1", Reference{Value: stringPtr("u1")}},
+		{
+			BackendTable, DisplayValueTrue,
+			map[string]string{"display_value": "Beth Anglin", "link": "https://x/u1"},
+			Reference{DisplayValue: stringPtr("Beth Anglin"), Link: stringPtr("https://x/u1")},
+		},
+		{
+			BackendTable, "",
+			map[string]string{"value": "u1", "link": "https://x/u1"},
+			Reference{Value: stringPtr("u1"), Link: stringPtr("https://x/u1")},
+		},
+	}
+	for _, tt := range tests {
+		client, mux := setup(t, WithBackend(tt.backend))
+		rec := map[string]interface{}{"number": "INC1", "assigned_to": tt.assignedTo, "priority": "2"}
+		mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, records(rec))
+		})
+		mux.HandleFunc("/api/now/table/incident", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, result([]map[string]interface{}{rec}))
+		})
+
+		incs, _, err := client.Incidents.List(context.Background(), ListOptions{DisplayValue: tt.display})
+		if err != nil {
+			t.Fatalf("backend %v, display %q: List returned error: %v", tt.backend, tt.display, err)
+		}
+		if got := incs[0].AssignedTo.Get(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("backend %v, display %q: AssignedTo = %v, want %v", tt.backend, tt.display, got, tt.want)
+		}
+		if got := incs[0].GetNumber(); got != "INC1" {
+			t.Errorf("backend %v, display %q: Number = %q, want INC1", tt.backend, tt.display, got)
+		}
+	}
+
+	// References decoded outside of the services keep bare strings in Value.
+	var ref Reference
+	if err := json.Unmarshal([]byte(`"u1"`), &ref); err != nil || ref.GetValue() != "u1" || ref.DisplayValue != nil {
+		t.Errorf("Unmarshal of a bare string = %v, %v, want Value u1", ref, err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -62,10 +62,14 @@ func main() {
 
 	for pkgName, pkg := range pkgs {
 		t := &templateData{
-			filename: pkgName + fileSuffix,
-			Year:     2020,
-			Package:  pkgName,
-			Imports:  map[string]string{},
+			filename:   pkgName + fileSuffix,
+			Year:       2020,
+			Package:    pkgName,
+			Imports:    map[string]string{},
+			basicTypes: map[string]string{},
+		}
+		for _, f := range pkg.Files {
+			t.collectBasicTypes(f)
 		}
 		for filename, f := range pkg.Files {
 			logf("Processing %v...", filename)
@@ -80,6 +84,35 @@ func main() {
 	logf("Done.")
 }
 
+// collectBasicTypes records the named types of f whose underlying type is a
+// basic type, such as IncidentState, with the zero value of that type.
+func (t *templateData) collectBasicTypes(f *ast.File) {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.TypeParams != nil {
+				continue
+			}
+			ident, ok := ts.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch ident.Name {
+			case "string":
+				t.basicTypes[ts.Name.Name] = `""`
+			case "int", "int64":
+				t.basicTypes[ts.Name.Name] = "0"
+			case "bool":
+				t.basicTypes[ts.Name.Name] = "false"
+			}
+		}
+	}
+}
+
 func (t *templateData) processAST(f *ast.File) error {
 	for _, decl := range f.Decls {
 		gd, ok := decl.(*ast.GenDecl)
@@ -101,6 +134,11 @@ func (t *templateData) processAST(f *ast.File) error {
 				logf("Struct %v is in skip list; skipping.", ts.Name)
 				continue
 			}
+			// Skip generic types, whose accessors would need type parameters.
+			if ts.TypeParams != nil {
+				logf("Struct %v is generic; skipping.", ts.Name)
+				continue
+			}
 			st, ok := ts.Type.(*ast.StructType)
 			if !ok {
 				continue
@@ -143,6 +181,8 @@ func (t *templateData) processAST(f *ast.File) error {
 					t.addMapType(x, ts.Name.String(), fieldName.String(), true)
 				case *ast.SelectorExpr:
 					t.addSelectorExpr(x, ts.Name.String(), fieldName.String())
+				case *ast.IndexExpr:
+					t.addField(x, ts.Name.String(), fieldName.String())
 				default:
 					logf("processAST: type %q, field %q, unknown %T: %+v", ts.Name, fieldName, x, x)
 				}
@@ -232,6 +272,10 @@ func (t *templateData) addIdent(x *ast.Ident, receiverType, fieldName string) {
 	case "Timestamp":
 		zeroValue = "Timestamp{}"
 	default:
+		if zv, ok := t.basicTypes[x.String()]; ok {
+			zeroValue = zv
+			break
+		}
 		zeroValue = "nil"
 		namedStruct = true
 	}
@@ -239,6 +283,23 @@ func (t *templateData) addIdent(x *ast.Ident, receiverType, fieldName string) {
 	t.Getters = append(t.Getters, newGetter(receiverType, fieldName, x.String(), zeroValue, namedStruct))
 }
 
+// addField adds a getter for a *Field[T] field, which returns the value of
+// the field, or a pointer to it if T is a struct other than Timestamp.
+func (t *templateData) addField(x *ast.IndexExpr, receiverType, fieldName string) {
+	if id, ok := x.X.(*ast.Ident); !ok || id.String() != "Field" {
+		logf("addField: type %q, field %q: unknown generic type %+v; skipping.", receiverType, fieldName, x.X)
+		return
+	}
+	id, ok := x.Index.(*ast.Ident)
+	if !ok {
+		logf("addField: type %q, field %q: unknown type argument %T %+v; skipping.", receiverType, fieldName, x.Index, x.Index)
+		return
+	}
+
+	t.addIdent(id, receiverType, fieldName)
+	t.Getters[len(t.Getters)-1].Field = true
+}
+
 func (t *templateData) addMapType(x *ast.MapType, receiverType, fieldName string, isAPointer bool) {
 	var keyType string
 	switch key := x.Key.(type) {
@@ -253,6 +314,18 @@ func (t *templateData) addMapType(x *ast.MapType, receiverType, fieldName string
 	switch value := x.Value.(type) {
 	case *ast.Ident:
 		valueType = value.String()
+	case *ast.SelectorExpr:
+		xx, ok := value.X.(*ast.Ident)
+		if !ok || (xx.String() != "json" && xx.String() != "time") {
+			logf("addMapType: type %q, field %q: unknown value type: %T %+v; skipping.", receiverType, fieldName, value, value)
+			return
+		}
+		if xx.String() == "json" {
+			t.Imports["encoding/json"] = "encoding/json"
+		} else {
+			t.Imports["time"] = "time"
+		}
+		valueType = fmt.Sprintf("%v.%v", xx, value.Sel.Name)
 	default:
 		logf("addMapType: type %q, field %q: unknown value type: %T %+v; skipping.", receiverType, fieldName, value, value)
 		return
@@ -294,11 +367,12 @@ func (t *templateData) addSelectorExpr(x *ast.SelectorExpr, receiverType, fieldN
 }
 
 type templateData struct {
-	filename string
-	Year     int
-	Package  string
-	Imports  map[string]string
-	Getters  []*getter
+	filename   string
+	basicTypes map[string]string // Zero values of named basic types.
+	Year       int
+	Package    string
+	Imports    map[string]string
+	Getters    []*getter
 }
 
 type getter struct {
@@ -309,6 +383,7 @@ type getter struct {
 	FieldType    string
 	ZeroValue    string
 	NamedStruct  bool // Getter for named struct.
+	Field        bool // Getter for Field.
 	MapType      bool
 }
 
@@ -330,7 +405,27 @@ import (
 )
 {{end}}
 {{range .Getters}}
-{{if .NamedStruct}}
+{{if and .Field .NamedStruct}}
+// Get{{.FieldName}} returns a copy of the value of the {{.FieldName}} field if it's set and not null, nil otherwise.
+func ({{.ReceiverVar}} *{{.ReceiverType}}) Get{{.FieldName}}() *{{.FieldType}} {
+  if {{.ReceiverVar}} == nil {
+    return {{.ZeroValue}}
+  }
+  v, ok := {{.ReceiverVar}}.{{.FieldName}}.Value()
+  if !ok {
+    return nil
+  }
+  return &v
+}
+{{else if .Field}}
+// Get{{.FieldName}} returns the value of the {{.FieldName}} field if it's set and not null, zero value otherwise.
+func ({{.ReceiverVar}} *{{.ReceiverType}}) Get{{.FieldName}}() {{.FieldType}} {
+  if {{.ReceiverVar}} == nil {
+    return {{.ZeroValue}}
+  }
+  return {{.ReceiverVar}}.{{.FieldName}}.Get()
+}
+{{else if .NamedStruct}}
 // Get{{.FieldName}} returns the {{.FieldName}} field.
 func ({{.ReceiverVar}} *{{.ReceiverType}}) Get{{.FieldName}}() *{{.FieldType}} {
   if {{.ReceiverVar}} == nil {
@@ -0,0 +1,199 @@
+//go:build ignore
+// +build ignore
+
+// gen-fields generates constants for the field names of record structs, that
+// is structs with an Extra field, from their json tags.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	fileSuffix = "-fields.go"
+)
+
+var (
+	verbose = flag.Bool("v", false, "Print verbose log messages")
+
+	sourceTmpl = template.Must(template.New("source").Parse(source))
+)
+
+func logf(fmt string, args ...interface{}) {
+	if *verbose {
+		log.Printf(fmt, args...)
+	}
+}
+
+func main() {
+	flag.Parse()
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, ".", sourceFilter, 0)
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	for pkgName, pkg := range pkgs {
+		t := &templateData{
+			filename: pkgName + fileSuffix,
+			Package:  pkgName,
+		}
+		for filename, f := range pkg.Files {
+			logf("Processing %v...", filename)
+			if err := t.processAST(f); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if err := t.dump(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	logf("Done.")
+}
+
+func (t *templateData) processAST(f *ast.File) error {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			// Skip unexported identifiers and generic types.
+			if !ts.Name.IsExported() || ts.TypeParams != nil {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || !isRecord(st) {
+				continue
+			}
+
+			r := &record{Name: ts.Name.Name}
+			for _, field := range st.Fields.List {
+				if len(field.Names) == 0 || field.Tag == nil {
+					continue
+				}
+				fieldName := field.Names[0]
+				if !fieldName.IsExported() {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err != nil {
+					return fmt.Errorf("%v.%v: %v", ts.Name, fieldName, err)
+				}
+				name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+				// Skip ignored fields and pseudo fields such as __status.
+				if name == "" || name == "-" || strings.HasPrefix(name, "__") {
+					logf("Field %v.%v is not a record field; skipping.", ts.Name, fieldName)
+					continue
+				}
+				r.Fields = append(r.Fields, &recordField{
+					ConstName: ts.Name.Name + "Field" + fieldName.Name,
+					Name:      name,
+				})
+			}
+			t.Records = append(t.Records, r)
+		}
+	}
+	return nil
+}
+
+// isRecord reports whether st is a record struct, that is whether it has an
+// Extra field holding the fields it does not declare.
+func isRecord(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "Extra" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sourceFilter(fi os.FileInfo) bool {
+	return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasSuffix(fi.Name(), fileSuffix)
+}
+
+func (t *templateData) dump() error {
+	if len(t.Records) == 0 {
+		logf("No records for %v; skipping.", t.filename)
+		return nil
+	}
+
+	sort.Slice(t.Records, func(i, j int) bool { return t.Records[i].Name < t.Records[j].Name })
+
+	var buf bytes.Buffer
+	if err := sourceTmpl.Execute(&buf, t); err != nil {
+		return err
+	}
+	clean, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format.Source:\n%v\n%v", buf.String(), err)
+	}
+
+	logf("Writing %v...", t.filename)
+	if err := os.Chmod(t.filename, 0644); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("os.Chmod(%q, 0644): %v", t.filename, err)
+	}
+
+	if err := ioutil.WriteFile(t.filename, clean, 0444); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(t.filename, 0444); err != nil {
+		return fmt.Errorf("os.Chmod(%q, 0444): %v", t.filename, err)
+	}
+
+	return nil
+}
+
+type templateData struct {
+	filename string
+	Package  string
+	Records  []*record
+}
+
+type record struct {
+	Name   string
+	Fields []*recordField
+}
+
+type recordField struct {
+	ConstName string
+	Name      string
+}
+
+const source = `
+// Code generated by gen-fields; DO NOT EDIT.
+
+package {{.Package}}
+{{range .Records}}
+// Field names of {{.Name}}, for use in ListOptions.Fields, GetOptions.Fields
+// and queries.
+const (
+{{- range .Fields}}
+  {{.ConstName}} = "{{.Name}}"
+{{- end}}
+)
+{{end}}
+`
@@ -0,0 +1,66 @@
+package servicenow
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generatedFiles are the files written by go generate, by generator.
+var generatedFiles = map[string]string{
+	"gen-accessors.go": "servicenow-accessors.go",
+	"gen-fields.go":    "servicenow-fields.go",
+}
+
+// TestGeneratedFiles checks that the generated files are up to date with the
+// structs they are generated from, by running the generators on a copy of the
+// package sources.
+func TestGeneratedFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go generate in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	dir := t.TempDir()
+	sources, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range sources {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for gen, generated := range generatedFiles {
+		cmd := exec.Command(goTool, "run", gen)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go run %s: %v\n%s", gen, err, out)
+		}
+		want, err := os.ReadFile(filepath.Join(dir, generated))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(generated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go generate", generated)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultExpiryDelta = 10 * time.Second
+
+// OAuthTransport is an http.RoundTripper that authenticates all requests with
+// an OAuth 2.0 access token issued by the instance's /oauth_token.do endpoint.
+//
+// The first token is obtained with the refresh_token grant if RefreshToken is
+// set, and with the password grant otherwise. Tokens are cached and refreshed
+// shortly before they expire, as well as when a request is rejected with
+// 401 Unauthorized.
+type OAuthTransport struct {
+	// TokenURL is the OAuth token endpoint of the instance, for example
+	// "https://instance.service-now.com/oauth_token.do".
+	TokenURL string
+
+	ClientID     string // OAuth client ID registered in the Application Registry
+	ClientSecret string // OAuth client secret registered in the Application Registry
+
+	Username string // ServiceNow username, used for the password grant
+	Password string // ServiceNow password, used for the password grant
+
+	// RefreshToken is used to obtain the first access token with the
+	// refresh_token grant instead of the password grant.
+	RefreshToken string
+
+	// ExpiryDelta is how long before its expiry a token is refreshed. It
+	// defaults to 10 seconds.
+	ExpiryDelta time.Duration
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu    sync.Mutex // mu protects token during refreshes.
+	token *oauthToken
+}
+
+type oauthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+
+	expiry time.Time
+}
+
+// OAuthError is returned when the token endpoint rejects a token request.
+type OAuthError struct {
+	Response    *http.Response // HTTP response that caused this error
+	Code        string         `json:"error"`             // OAuth error code, such as "server_error"
+	Description string         `json:"error_description"` // Error description, such as "access_denied"
+}
+
+func (e *OAuthError) Error() string {
+	u := *e.Response.Request.URL
+	msg := fmt.Sprintf("oauth: %v %v: %d %v", e.Response.Request.Method, sanitizeURL(&u), e.Response.StatusCode, e.Code)
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *OAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.accessToken(req.Context(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport().RoundTrip(setBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token may have been revoked before its expiry. Refresh it and replay
+	// the request once, provided its body can be replayed.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	refreshed, err := t.accessToken(req.Context(), token)
+	if err != nil {
+		return resp, nil
+	}
+	req2 := setBearerToken(req, refreshed)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		req2.Body = body
+	}
+	discardBody(resp)
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using OAuth 2.0.
+func (t *OAuthTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *OAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// accessToken returns a valid access token, requesting a new one if there is
+// no cached token, if the cached token is about to expire, or if it is stale.
+func (t *OAuthTransport) accessToken(ctx context.Context, stale string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && t.token.AccessToken != stale && t.valid(t.token) {
+		return t.token.AccessToken, nil
+	}
+
+	refreshToken := t.RefreshToken
+	if t.token != nil && t.token.RefreshToken != "" {
+		refreshToken = t.token.RefreshToken
+	}
+
+	var token *oauthToken
+	var err error
+	if refreshToken != "" {
+		token, err = t.requestToken(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshToken},
+		})
+	}
+	if refreshToken == "" || (err != nil && t.Username != "") {
+		token, err = t.requestToken(ctx, url.Values{
+			"grant_type": {"password"},
+			"username":   {t.Username},
+			"password":   {t.Password},
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	t.token = token
+	return token.AccessToken, nil
+}
+
+func (t *OAuthTransport) valid(token *oauthToken) bool {
+	if token.expiry.IsZero() {
+		return true
+	}
+	delta := t.ExpiryDelta
+	if delta <= 0 {
+		delta = defaultExpiryDelta
+	}
+	return time.Now().Add(delta).Before(token.expiry)
+}
+
+// requestToken requests a token from the token endpoint using the grant
+// described by params.
+func (t *OAuthTransport) requestToken(ctx context.Context, params url.Values) (*oauthToken, error) {
+	if t.TokenURL == "" {
+		return nil, errors.New("oauth: TokenURL must be set")
+	}
+	params.Set("client_id", t.ClientID)
+	params.Set("client_secret", t.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		// Don't let the token URL leak credentials through the error.
+		return nil, &url.Error{Op: "Post", URL: sanitizeURL(req.URL).String(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if c := resp.StatusCode; c < 200 || c > 299 {
+		oauthErr := &OAuthError{Response: resp}
+		json.Unmarshal(data, oauthErr)
+		if oauthErr.Code == "" {
+			oauthErr.Code = http.StatusText(resp.StatusCode)
+		}
+		return nil, oauthErr
+	}
+
+	token := &oauthToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("oauth: cannot decode token response: %v", err)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("oauth: server response missing access_token")
+	}
+	if token.ExpiresIn > 0 {
+		token.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+func setBearerToken(req *http.Request, token string) *http.Request {
+	convertedRequest := cloneRequest(req)
+	convertedRequest.Header.Set("Authorization", "Bearer "+token)
+	return convertedRequest
+}
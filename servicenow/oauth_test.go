@@ -0,0 +1,201 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenServer is a fake /oauth_token.do endpoint that issues sequentially
+// numbered access tokens.
+type tokenServer struct {
+	t         *testing.T
+	expiresIn int
+
+	mu     sync.Mutex
+	grants []string // grant_type of each token request
+	issued int
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	testMethod(s.t, r, "POST")
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatalf("ParseForm returned error: %v", err)
+	}
+	if got := r.PostForm.Get("client_id"); got != "id" {
+		s.t.Errorf("client_id = %q, want %q", got, "id")
+	}
+	if got := r.PostForm.Get("client_secret"); got != "secret" {
+		s.t.Errorf("client_secret = %q, want %q", got, "secret")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grant := r.PostForm.Get("grant_type")
+	s.grants = append(s.grants, grant)
+	switch grant {
+	case "password":
+		if r.PostForm.Get("username") != "admin" || r.PostForm.Get("password") != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"server_error","error_description":"access_denied"}`)
+			return
+		}
+	case "refresh_token":
+		if !strings.HasPrefix(r.PostForm.Get("refresh_token"), "refresh-") {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+	}
+	s.issued++
+	writeJSON(s.t, w, map[string]interface{}{
+		"access_token":  fmt.Sprintf("token-%d", s.issued),
+		"refresh_token": fmt.Sprintf("refresh-%d", s.issued),
+		"token_type":    "Bearer",
+		"expires_in":    s.expiresIn,
+	})
+}
+
+func (s *tokenServer) grantTypes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.grants...)
+}
+
+// setupOAuth returns a Client authenticating with an OAuthTransport against
+// a fake token endpoint, and the mux of the fake instance.
+func setupOAuth(t *testing.T, ts *tokenServer, tp *OAuthTransport) (*Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle("/oauth_token.do", ts)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	tp.TokenURL = server.URL + "/oauth_token.do"
+	tp.ClientID, tp.ClientSecret = "id", "secret"
+	client, err := NewClient(server.URL, tp.Client())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return client, mux
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOAuthTransport_passwordGrant(t *testing.T) {
+	ts := &tokenServer{t: t, expiresIn: 1800}
+	client, mux := setupOAuth(t, ts, &OAuthTransport{Username: "admin", Password: "pw"})
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer token-1"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		writeJSON(t, w, records())
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Incidents.List(context.Background(), ListOptions{}); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+	if got, want := ts.grantTypes(), []string{"password"}; !equalStrings(got, want) {
+		t.Errorf("token requests = %v, want %v (token cached)", got, want)
+	}
+}
+
+func TestOAuthTransport_refreshBeforeExpiry(t *testing.T) {
+	ts := &tokenServer{t: t, expiresIn: 5}
+	tp := &OAuthTransport{Username: "admin", Password: "pw", ExpiryDelta: time.Minute}
+	client, mux := setupOAuth(t, ts, tp)
+	var auth []string
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		writeJSON(t, w, records())
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Incidents.List(context.Background(), ListOptions{}); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+	// A new token is used once, then refreshed with its refresh token since it
+	// expires within ExpiryDelta.
+	if got, want := ts.grantTypes(), []string{"password", "refresh_token"}; !equalStrings(got, want) {
+		t.Errorf("token requests = %v, want %v", got, want)
+	}
+	if want := []string{"Bearer token-1", "Bearer token-2"}; !equalStrings(auth, want) {
+		t.Errorf("Authorization headers = %v, want %v", auth, want)
+	}
+}
+
+func TestOAuthTransport_refreshTokenGrant(t *testing.T) {
+	ts := &tokenServer{t: t, expiresIn: 1800}
+	client, mux := setupOAuth(t, ts, &OAuthTransport{RefreshToken: "refresh-0"})
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, records())
+	})
+
+	if _, _, err := client.Incidents.List(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got, want := ts.grantTypes(), []string{"refresh_token"}; !equalStrings(got, want) {
+		t.Errorf("token requests = %v, want %v", got, want)
+	}
+}
+
+func TestOAuthTransport_retryOnUnauthorized(t *testing.T) {
+	ts := &tokenServer{t: t, expiresIn: 1800}
+	client, mux := setupOAuth(t, ts, &OAuthTransport{Username: "admin", Password: "pw"})
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		// The first token has been revoked.
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(t, w, records(map[string]interface{}{"number": "INC1"}))
+	})
+
+	incs, _, err := client.Incidents.List(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(incs) != 1 || incs[0].GetNumber() != "INC1" {
+		t.Errorf("List returned %v, want the record fetched with the refreshed token", incs)
+	}
+	if got, want := ts.grantTypes(), []string{"password", "refresh_token"}; !equalStrings(got, want) {
+		t.Errorf("token requests = %v, want %v", got, want)
+	}
+}
+
+func TestOAuthTransport_tokenError(t *testing.T) {
+	ts := &tokenServer{t: t, expiresIn: 1800}
+	client, mux := setupOAuth(t, ts, &OAuthTransport{Username: "admin", Password: "wrong"})
+	mux.HandleFunc("/incident.do", failHandler(t))
+
+	_, _, err := client.Incidents.List(context.Background(), ListOptions{})
+	var e *OAuthError
+	if !errors.As(err, &e) {
+		t.Fatalf("List returned error %v, want *OAuthError", err)
+	}
+	if e.Code != "server_error" || e.Description != "access_denied" {
+		t.Errorf("OAuthError = %q, %q, want server_error, access_denied", e.Code, e.Description)
+	}
+	if msg := err.Error(); strings.Contains(msg, "secret") || strings.Contains(msg, "wrong") {
+		t.Errorf("error %q leaks credentials", msg)
+	}
+}
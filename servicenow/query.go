@@ -0,0 +1,497 @@
+package servicenow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Lt          OperandType = "<"
+	Le          OperandType = "<="
+	Gt          OperandType = ">"
+	Ge          OperandType = ">="
+	NOTLIKE     OperandType = "NOT LIKE"
+	IN          OperandType = "IN"
+	NOTIN       OperandType = "NOT IN"
+	ISEMPTY     OperandType = "ISEMPTY"
+	ISNOTEMPTY  OperandType = "ISNOTEMPTY"
+	BETWEEN     OperandType = "BETWEEN"
+	NQ          OperandType = "^NQ"
+	ORDERBY     OperandType = "ORDERBY"
+	ORDERBYDESC OperandType = "ORDERBYDESC"
+)
+
+// queryTimeLayout is the layout of date and time values in encoded queries.
+const queryTimeLayout = "2006-01-02 15:04:05"
+
+var (
+	// queryFieldRE matches field names, including dot-walked ones such as
+	// assignment_group.name.
+	queryFieldRE = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+	// queryOpRE matches operators, such as = or NOT LIKE.
+	queryOpRE = regexp.MustCompile(`^[A-Z<>=!]+( [A-Z]+)*$`)
+)
+
+// QueryScript is a script value of an encoded query, such as
+// javascript:gs.daysAgo(7). Query only accepts scripts as values if they are
+// of type QueryScript, so that scripts cannot be injected through plain
+// string values.
+type QueryScript string
+
+// InvalidQueryError reports a field, operator or value that would change the
+// meaning of an encoded query, for example a value containing ^, which would
+// start a new condition.
+type InvalidQueryError struct {
+	Field  string // Field of the condition
+	Value  string // Offending field name, operator or value
+	Reason string // Why Value was rejected
+}
+
+func (e *InvalidQueryError) Error() string {
+	return fmt.Sprintf("invalid query condition on %q: %q %s", e.Field, e.Value, e.Reason)
+}
+
+// Condition is a single condition of an encoded query, such as priority<=2.
+type Condition struct {
+	Field string
+	Op    OperandType
+	Value string
+}
+
+func (c Condition) String() string {
+	if c.Op == ISEMPTY || c.Op == ISNOTEMPTY {
+		return c.Field + string(c.Op)
+	}
+	return c.Field + string(c.Op) + c.Value
+}
+
+// Query builds an encoded query, as used by sysparm_query. Conditions are
+// ANDed together unless they are preceded by a call to Or, which makes a
+// condition an alternative to the one before it, or NQ, which starts a new
+// query whose results are added to those of the previous one.
+//
+// As in ServiceNow, OR binds tighter than AND, which binds tighter than NQ:
+//
+//	servicenow.NewQuery().
+//		Eq("active", true).
+//		Eq("priority", 1).Or().Eq("priority", 2).
+//		NQ().
+//		IsEmpty("assigned_to").
+//		OrderByDesc("sys_created_on")
+//
+// encodes as
+//
+//	active=true^priority=1^ORpriority=2^NQassigned_toISEMPTY^ORDERBYDESCsys_created_on
+//
+// that is (active AND (priority 1 OR priority 2)) OR unassigned, newest first.
+// The zero value is an empty query ready to use.
+//
+// Values are checked before they are added: a value containing ^, which would
+// smuggle extra conditions into the query, or a string starting with
+// javascript: is rejected, as are malformed field names. The condition is then
+// left out and the error is reported by Err and Encode, and by any List call
+// given the query.
+type Query struct {
+	groups  []queryGroup // groups are joined by ^NQ
+	orderBy []string     // ORDERBY and ORDERBYDESC clauses
+	err     error        // first invalid condition
+
+	or bool // whether the next condition is an alternative to the previous one
+	nq bool // whether the next condition starts a new group
+}
+
+// queryGroup is a list of clauses joined by ^.
+type queryGroup []queryClause
+
+// queryClause is a list of alternative conditions joined by ^OR.
+type queryClause []Condition
+
+// NewQuery returns a new empty Query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Where adds the condition field op value. value is formatted as described
+// for Eq.
+func (q *Query) Where(field string, op OperandType, value interface{}) *Query {
+	v, err := queryValue(field, value)
+	if err != nil {
+		return q.fail(err)
+	}
+	return q.add(Condition{Field: field, Op: op, Value: v})
+}
+
+// Eq adds the condition field=value. Strings, QueryScripts and choices such as
+// IncidentStateResolved are used as is, booleans and numbers are formatted in
+// decimal, and times are formatted in UTC as "2006-01-02 15:04:05". Other
+// values are formatted with fmt.Sprint.
+func (q *Query) Eq(field string, value interface{}) *Query {
+	return q.Where(field, Eq, value)
+}
+
+// Ne adds the condition field!=value.
+func (q *Query) Ne(field string, value interface{}) *Query {
+	return q.Where(field, Ne, value)
+}
+
+// Lt adds the condition field<value.
+func (q *Query) Lt(field string, value interface{}) *Query {
+	return q.Where(field, Lt, value)
+}
+
+// Le adds the condition field<=value.
+func (q *Query) Le(field string, value interface{}) *Query {
+	return q.Where(field, Le, value)
+}
+
+// Gt adds the condition field>value.
+func (q *Query) Gt(field string, value interface{}) *Query {
+	return q.Where(field, Gt, value)
+}
+
+// Ge adds the condition field>=value.
+func (q *Query) Ge(field string, value interface{}) *Query {
+	return q.Where(field, Ge, value)
+}
+
+// Like adds the condition that field contains value.
+func (q *Query) Like(field string, value interface{}) *Query {
+	return q.Where(field, LIKE, value)
+}
+
+// NotLike adds the condition that field does not contain value.
+func (q *Query) NotLike(field string, value interface{}) *Query {
+	return q.Where(field, NOTLIKE, value)
+}
+
+// StartsWith adds the condition that field starts with value.
+func (q *Query) StartsWith(field string, value interface{}) *Query {
+	return q.Where(field, STARTSWITH, value)
+}
+
+// EndsWith adds the condition that field ends with value.
+func (q *Query) EndsWith(field string, value interface{}) *Query {
+	return q.Where(field, ENDSWITH, value)
+}
+
+// In adds the condition that field is one of values.
+func (q *Query) In(field string, values ...interface{}) *Query {
+	return q.addList(field, IN, values)
+}
+
+// NotIn adds the condition that field is none of values.
+func (q *Query) NotIn(field string, values ...interface{}) *Query {
+	return q.addList(field, NOTIN, values)
+}
+
+// addList adds a condition whose value is a comma separated list of values.
+func (q *Query) addList(field string, op OperandType, values []interface{}) *Query {
+	s := make([]string, len(values))
+	for i, value := range values {
+		v, err := queryValue(field, value)
+		if err != nil {
+			return q.fail(err)
+		}
+		if strings.Contains(v, ",") {
+			return q.fail(&InvalidQueryError{Field: field, Value: v, Reason: "contains , which separates list values"})
+		}
+		s[i] = v
+	}
+	return q.add(Condition{Field: field, Op: op, Value: strings.Join(s, ",")})
+}
+
+// IsEmpty adds the condition that field is empty.
+func (q *Query) IsEmpty(field string) *Query {
+	return q.add(Condition{Field: field, Op: ISEMPTY})
+}
+
+// IsNotEmpty adds the condition that field is not empty.
+func (q *Query) IsNotEmpty(field string) *Query {
+	return q.add(Condition{Field: field, Op: ISNOTEMPTY})
+}
+
+// Between adds the condition that field is between from and to, inclusive.
+func (q *Query) Between(field string, from, to interface{}) *Query {
+	var bounds [2]string
+	for i, value := range []interface{}{from, to} {
+		v, err := queryValue(field, value)
+		if err != nil {
+			return q.fail(err)
+		}
+		if strings.Contains(v, "@") {
+			return q.fail(&InvalidQueryError{Field: field, Value: v, Reason: "contains @ which separates range bounds"})
+		}
+		bounds[i] = v
+	}
+	return q.add(Condition{Field: field, Op: BETWEEN, Value: bounds[0] + "@" + bounds[1]})
+}
+
+// Or makes the next condition an alternative to the previous one.
+func (q *Query) Or() *Query {
+	q.or = true
+	return q
+}
+
+// NQ starts a new query. Records that match either the conditions before or
+// the conditions after NQ are returned.
+func (q *Query) NQ() *Query {
+	q.nq = true
+	return q
+}
+
+// OrderBy sorts the results by field in ascending order.
+func (q *Query) OrderBy(field string) *Query {
+	if err := validateQueryField(field); err != nil {
+		return q.fail(err)
+	}
+	q.orderBy = append(q.orderBy, string(ORDERBY)+field)
+	return q
+}
+
+// OrderByDesc sorts the results by field in descending order.
+func (q *Query) OrderByDesc(field string) *Query {
+	if err := validateQueryField(field); err != nil {
+		return q.fail(err)
+	}
+	q.orderBy = append(q.orderBy, string(ORDERBYDESC)+field)
+	return q
+}
+
+// Err returns the first invalid condition added to the query, if any.
+func (q *Query) Err() error {
+	if q == nil {
+		return nil
+	}
+	return q.err
+}
+
+// Encode returns the encoded query, or an error if an invalid condition was
+// added to it.
+func (q *Query) Encode() (string, error) {
+	if err := q.Err(); err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+// fail records err and leaves the query otherwise unchanged.
+func (q *Query) fail(err error) *Query {
+	if q.err == nil {
+		q.err = err
+	}
+	q.or, q.nq = false, false
+	return q
+}
+
+// add adds c to the query, joined according to the pending Or or NQ call.
+func (q *Query) add(c Condition) *Query {
+	if err := validateCondition(c); err != nil {
+		return q.fail(err)
+	}
+
+	switch n := len(q.groups); {
+	case n == 0 || q.nq:
+		q.groups = append(q.groups, queryGroup{{c}})
+	case q.or:
+		g := q.groups[n-1]
+		g[len(g)-1] = append(g[len(g)-1], c)
+	default:
+		q.groups[n-1] = append(q.groups[n-1], queryClause{c})
+	}
+	q.or, q.nq = false, false
+	return q
+}
+
+// and returns a copy of q with conds ANDed to each of its groups.
+func (q *Query) and(conds ...Condition) *Query {
+	res := &Query{orderBy: q.orderBy, err: q.err}
+	for _, g := range q.groups {
+		group := append(queryGroup(nil), g...)
+		for _, c := range conds {
+			group = append(group, queryClause{c})
+		}
+		res.groups = append(res.groups, group)
+	}
+	if len(res.groups) == 0 && len(conds) > 0 {
+		group := queryGroup{}
+		for _, c := range conds {
+			group = append(group, queryClause{c})
+		}
+		res.groups = append(res.groups, group)
+	}
+	return res
+}
+
+// String returns the encoded query.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, g := range q.groups {
+		var clauses []string
+		for _, clause := range g {
+			var alts []string
+			for _, c := range clause {
+				alts = append(alts, c.String())
+			}
+			clauses = append(clauses, strings.Join(alts, string(LOR)))
+		}
+		parts = append(parts, strings.Join(clauses, string(AND)))
+	}
+	encoded := strings.Join(parts, string(NQ))
+
+	if len(q.orderBy) > 0 {
+		if encoded != "" {
+			encoded += string(AND)
+		}
+		encoded += strings.Join(q.orderBy, string(AND))
+	}
+	return encoded
+}
+
+// encodedQuery returns the encoded query for the Query and QueryOpts of opts.
+// QueryOpts conditions are ANDed to the query. An encoded query already set in
+// SysparmQuery is used as is, and cannot be combined with Query or QueryOpts.
+func (o ListOptions) encodedQuery() (string, error) {
+	if o.SysparmQuery != "" {
+		if o.Query != nil || len(o.QueryOpts) > 0 {
+			return "", errors.New("SysparmQuery cannot be combined with Query or QueryOpts")
+		}
+		return o.SysparmQuery, nil
+	}
+	return encodeQuery(o.Query, o.QueryOpts)
+}
+
+// encodeQuery returns the encoded query that ANDs the conditions of opts to
+// q, either of which may be empty.
+func encodeQuery(q *Query, opts []QueryOpts) (string, error) {
+	if q == nil {
+		q = &Query{}
+	}
+	if len(opts) == 0 {
+		return q.Encode()
+	}
+
+	conds := make([]Condition, len(opts))
+	for i, v := range opts {
+		conds[i] = Condition{Field: v.Key, Op: v.Op, Value: v.Val}
+		if err := validateQueryValue(v.Key, v.Val); err != nil {
+			return "", err
+		}
+		if err := validateCondition(conds[i]); err != nil {
+			return "", err
+		}
+	}
+	return q.and(conds...).Encode()
+}
+
+// fieldQuery returns the encoded query that selects the records whose field
+// equals value.
+func fieldQuery(field, value string) (string, error) {
+	return NewQuery().Eq(field, value).Encode()
+}
+
+// DaysAgo returns a query value for the time n days ago.
+func DaysAgo(n int) QueryScript {
+	return QueryScript(fmt.Sprintf("javascript:gs.daysAgo(%d)", n))
+}
+
+// DaysAgoStart returns a query value for the start of the day n days ago.
+func DaysAgoStart(n int) QueryScript {
+	return QueryScript(fmt.Sprintf("javascript:gs.daysAgoStart(%d)", n))
+}
+
+// DaysAgoEnd returns a query value for the end of the day n days ago.
+func DaysAgoEnd(n int) QueryScript {
+	return QueryScript(fmt.Sprintf("javascript:gs.daysAgoEnd(%d)", n))
+}
+
+// HoursAgo returns a query value for the time n hours ago.
+func HoursAgo(n int) QueryScript {
+	return QueryScript(fmt.Sprintf("javascript:gs.hoursAgo(%d)", n))
+}
+
+// MinutesAgo returns a query value for the time n minutes ago.
+func MinutesAgo(n int) QueryScript {
+	return QueryScript(fmt.Sprintf("javascript:gs.minutesAgo(%d)", n))
+}
+
+// queryValue formats v as a value of a condition on field, and validates it.
+func queryValue(field string, v interface{}) (string, error) {
+	if script, ok := v.(QueryScript); ok {
+		if strings.Contains(string(script), "^") {
+			return "", &InvalidQueryError{Field: field, Value: string(script), Reason: "contains ^ which separates conditions"}
+		}
+		return string(script), nil
+	}
+	s := formatQueryValue(v)
+	return s, validateQueryValue(field, s)
+}
+
+// validateQueryValue reports whether v can be used as a value of a condition
+// on field without changing the structure of the query.
+func validateQueryValue(field, v string) error {
+	if strings.Contains(v, "^") {
+		return &InvalidQueryError{Field: field, Value: v, Reason: "contains ^ which separates conditions"}
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(v)), "javascript:") {
+		return &InvalidQueryError{Field: field, Value: v, Reason: "is a script, use QueryScript for trusted scripts"}
+	}
+	return nil
+}
+
+// validateCondition reports whether the field name and operator of c are
+// valid. The value is validated when the condition is built.
+func validateCondition(c Condition) error {
+	if err := validateQueryField(c.Field); err != nil {
+		return err
+	}
+	if !queryOpRE.MatchString(string(c.Op)) {
+		return &InvalidQueryError{Field: c.Field, Value: string(c.Op), Reason: "is not an operator"}
+	}
+	return nil
+}
+
+// validateQueryField reports whether field is a valid field name.
+func validateQueryField(field string) error {
+	if !queryFieldRE.MatchString(field) {
+		return &InvalidQueryError{Field: field, Value: field, Reason: "is not a valid field name"}
+	}
+	return nil
+}
+
+func formatQueryValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(queryTimeLayout)
+	case Timestamp:
+		return v.UTC().Format(queryTimeLayout)
+	case *Timestamp:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(queryTimeLayout)
+	}
+
+	// Use the value of choices, such as IncidentStateResolved, rather than
+	// the label returned by their String method.
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,279 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryScriptRE matches the scripts returned by DaysAgo and the related
+// helpers, which are the only scripts Match can evaluate.
+var queryScriptRE = regexp.MustCompile(`^javascript:gs\.(daysAgo|daysAgoStart|daysAgoEnd|hoursAgo|minutesAgo)\((-?\d+)\)$`)
+
+// Match reports whether record matches q. It evaluates q locally, without
+// calling the instance, and can be used to filter cached records or to test
+// filters.
+//
+// record is a struct or pointer to struct, whose fields are named by their
+// json tags and which may carry further fields in Extra, or a map keyed by
+// field name. A reference field given as an object compares by its value.
+//
+// Dot-walked fields, such as assignment_group.name, are looked up as fields of
+// that name first, which is how the Table API returns them when they are
+// listed in ListOptions.Fields; record structs keep them in Extra. They are
+// then looked up in nested maps and structs. Reference fields of record
+// structs hold only the sys_id and display value of the referenced record, not
+// its fields, so a dot-walked field that was not fetched is empty.
+//
+// Like the instance, Match compares strings regardless of case, and compares
+// values as numbers or times when both sides parse as such. Missing fields are
+// empty. ORDERBY clauses are ignored.
+func (q *Query) Match(record interface{}) (bool, error) {
+	if err := q.Err(); err != nil {
+		return false, err
+	}
+	if q == nil || len(q.groups) == 0 {
+		return true, nil
+	}
+
+	fields, err := recordFields(record)
+	if err != nil {
+		return false, err
+	}
+
+	for _, g := range q.groups {
+		ok, err := matchGroup(g, fields)
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// FilterRecords returns the records that match q, as reported by Match.
+func FilterRecords[T any](q *Query, records []*T) ([]*T, error) {
+	var res []*T
+	for _, r := range records {
+		ok, err := q.Match(r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func matchGroup(g queryGroup, fields map[string]interface{}) (bool, error) {
+	for _, clause := range g {
+		ok, err := matchClause(clause, fields)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchClause(clause queryClause, fields map[string]interface{}) (bool, error) {
+	for _, c := range clause {
+		ok, err := c.Match(lookupField(fields, c.Field))
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Match reports whether a field whose value is v matches c.
+func (c Condition) Match(v string) (bool, error) {
+	switch c.Op {
+	case ISEMPTY:
+		return v == "", nil
+	case ISNOTEMPTY:
+		return v != "", nil
+	case IN, NOTIN:
+		found := false
+		for _, want := range strings.Split(c.Value, ",") {
+			want, err := evalQueryValue(want)
+			if err != nil {
+				return false, err
+			}
+			if compareQueryValues(v, want) == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (c.Op == IN), nil
+	case BETWEEN:
+		bounds := strings.SplitN(c.Value, "@", 2)
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("%s: BETWEEN value must be of the form from@to", c)
+		}
+		from, err := evalQueryValue(bounds[0])
+		if err != nil {
+			return false, err
+		}
+		to, err := evalQueryValue(bounds[1])
+		if err != nil {
+			return false, err
+		}
+		return v != "" && compareQueryValues(v, from) >= 0 && compareQueryValues(v, to) <= 0, nil
+	}
+
+	want, err := evalQueryValue(c.Value)
+	if err != nil {
+		return false, err
+	}
+	lv, lwant := strings.ToLower(v), strings.ToLower(want)
+	switch c.Op {
+	case Eq:
+		return compareQueryValues(v, want) == 0, nil
+	case Ne:
+		return compareQueryValues(v, want) != 0, nil
+	case Lt:
+		return v != "" && compareQueryValues(v, want) < 0, nil
+	case Le:
+		return v != "" && compareQueryValues(v, want) <= 0, nil
+	case Gt:
+		return v != "" && compareQueryValues(v, want) > 0, nil
+	case Ge:
+		return v != "" && compareQueryValues(v, want) >= 0, nil
+	case LIKE:
+		return strings.Contains(lv, lwant), nil
+	case NOTLIKE:
+		return !strings.Contains(lv, lwant), nil
+	case STARTSWITH:
+		return strings.HasPrefix(lv, lwant), nil
+	case ENDSWITH:
+		return strings.HasSuffix(lv, lwant), nil
+	}
+	return false, fmt.Errorf("%s: operator %s cannot be evaluated", c, c.Op)
+}
+
+// evalQueryValue returns the value v of a condition, evaluating it if it is
+// one of the scripts returned by DaysAgo and the related helpers.
+func evalQueryValue(v string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(v)), "javascript:") {
+		return v, nil
+	}
+
+	m := queryScriptRE.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return "", fmt.Errorf("script %q cannot be evaluated", v)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", fmt.Errorf("script %q cannot be evaluated: %v", v, err)
+	}
+
+	now := time.Now().UTC()
+	var t time.Time
+	switch m[1] {
+	case "daysAgo":
+		t = now.AddDate(0, 0, -n)
+	case "daysAgoStart":
+		y, mo, d := now.AddDate(0, 0, -n).Date()
+		t = time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	case "daysAgoEnd":
+		y, mo, d := now.AddDate(0, 0, -n).Date()
+		t = time.Date(y, mo, d, 23, 59, 59, 0, time.UTC)
+	case "hoursAgo":
+		t = now.Add(-time.Duration(n) * time.Hour)
+	case "minutesAgo":
+		t = now.Add(-time.Duration(n) * time.Minute)
+	}
+	return t.Format(queryTimeLayout), nil
+}
+
+// compareQueryValues compares a and b as numbers if both are numbers, as times
+// if both are times, and as case-folded strings otherwise.
+func compareQueryValues(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := parseQueryTime(a); ok {
+		if y, ok := parseQueryTime(b); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// parseQueryTime parses s as a time in the layout of encoded queries or in
+// RFC 3339.
+func parseQueryTime(s string) (time.Time, bool) {
+	for _, layout := range []string{queryTimeLayout, "2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// recordFields returns the fields of record as decoded from its encoding by
+// MarshalRecord, so that json tags, Extra and custom marshalers are honoured.
+func recordFields(record interface{}) (map[string]interface{}, error) {
+	data, err := MarshalRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("record of type %T cannot be matched: %v", record, err)
+	}
+	return fields, nil
+}
+
+// lookupField returns the value of the possibly dot-walked field of fields,
+// or "" if there is no such field. Dot-walked fields are also looked up as is,
+// which is how the instance returns them when they are selected.
+func lookupField(fields map[string]interface{}, field string) string {
+	if v, ok := fields[field]; ok {
+		return fieldString(v)
+	}
+	var v interface{} = fields
+	for _, name := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = m[name]
+	}
+	return fieldString(v)
+}
+
+// fieldString returns the value of a decoded JSON field as a string. Objects,
+// such as references, are represented by their value.
+func fieldString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}:
+		return fieldString(v["value"])
+	}
+	return fmt.Sprint(v)
+}
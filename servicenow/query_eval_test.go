@@ -0,0 +1,79 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestQuery_Match(t *testing.T) {
+	inc := &Incident{
+		Number:           NewField("INC0000001"),
+		Priority:         NewField(PriorityHigh),
+		State:            NewField(IncidentStateInProgress),
+		ShortDescription: NewField("Disk full on db01"),
+		AssignmentGroup:  NewField(*NewReference("a1")),
+		OpenedAt:         NewField(Timestamp{time.Now().Add(-2 * time.Hour)}),
+		Extra:            map[string]json.RawMessage{"u_team": json.RawMessage(`"sre"`)},
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"number=INC0000001", true},
+		{"number=inc0000001", true},
+		{"priority<=2", true},
+		{"priority<2", false},
+		{"state!=6^short_descriptionLIKEdisk", true},
+		{"stateIN6,7^ORpriority=2", true},
+		{"state=6^NQnumberSTARTSWITHINC", true},
+		{"state=6^NQnumberENDSWITH2", false},
+		{"assignment_group=a1", true},
+		{"assigned_toISEMPTY", true},
+		{"u_team=SRE", true},
+		{"opened_at>javascript:gs.hoursAgo(3)", true},
+		{"opened_at>javascript:gs.hoursAgo(1)", false},
+		{"priorityBETWEEN1@2", true},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) returned error: %v", tt.query, err)
+		}
+		got, err := q.Match(inc)
+		if err != nil {
+			t.Errorf("Match(%q) returned error: %v", tt.query, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQuery_Match_dotWalked(t *testing.T) {
+	q, err := CompileFilter(`assignment_group.name = "SRE"`)
+	if err != nil {
+		t.Fatalf("CompileFilter returned error: %v", err)
+	}
+
+	// Reference fields only hold the sys_id and display value of the
+	// referenced record.
+	sre := "SRE"
+	inc := &Incident{AssignmentGroup: NewField(Reference{DisplayValue: &sre})}
+	if ok, _ := q.Match(inc); ok {
+		t.Errorf("Match on a Reference display value = true, want false")
+	}
+
+	// Dot-walked fields fetched with ListOptions.Fields are kept in Extra.
+	inc.Extra = map[string]json.RawMessage{"assignment_group.name": json.RawMessage(`"SRE"`)}
+	if ok, _ := q.Match(inc); !ok {
+		t.Errorf("Match on a fetched dot-walked field = false, want true")
+	}
+
+	// Maps may nest the referenced record.
+	m := map[string]interface{}{"assignment_group": map[string]interface{}{"name": "SRE"}}
+	if ok, _ := q.Match(m); !ok {
+		t.Errorf("Match on a nested map = false, want true")
+	}
+}
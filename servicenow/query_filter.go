@@ -0,0 +1,604 @@
+package servicenow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFilterGroups bounds the number of ^NQ groups a filter may expand to, as
+// ANDing disjunctions multiplies their number.
+const maxFilterGroups = 64
+
+// FilterSymbols maps field names to the symbolic values that can be used for
+// them in filters, keyed by lower case symbol. For example, with the default
+// symbols, state != resolved compiles to state!=6. Symbols are derived from
+// the labels of choices by Choices.FilterSymbols.
+type FilterSymbols map[string]map[string]string
+
+// DefaultFilterSymbols returns the symbols of the choice fields of
+// incidents, derived from the current DefaultChoices.
+func DefaultFilterSymbols() FilterSymbols {
+	return DefaultChoices.FilterSymbols(IncidentTable)
+}
+
+// FilterCompiler compiles filters written in a small infix language to
+// queries. A filter such as
+//
+//	priority <= 2 and state != resolved and assignment_group.name = "SRE"
+//
+// compiles to priority<=2^state!=6^assignment_group.name=SRE.
+//
+// Conditions compare a field to a value with =, !=, <, <=, > or >=, or are
+// one of
+//
+//	field [not] in (value, ...)
+//	field [not] like value        (also contains)
+//	field startswith value
+//	field endswith value
+//	field [not] between value and value
+//	field is [not] empty
+//
+// and are combined with and, or, not and parentheses. Keywords are case
+// insensitive. Values are numbers, double quoted strings, true, false, the
+// relative times daysAgo(n), daysAgoStart(n), daysAgoEnd(n), hoursAgo(n) and
+// minutesAgo(n), or bare words, which are looked up in the symbols of the
+// field. A filter may end with order by field [asc|desc], ....
+type FilterCompiler struct {
+	// Symbols are the symbolic values of fields. If nil, those returned by
+	// DefaultFilterSymbols are used. Client.FilterCompiler returns a
+	// FilterCompiler with the symbols of the choices of a client.
+	Symbols FilterSymbols
+}
+
+// CompileFilter compiles src with the default symbols. See FilterCompiler.
+func CompileFilter(src string) (*Query, error) {
+	return (&FilterCompiler{}).Compile(src)
+}
+
+// Compile compiles src to a query. Errors are of type *SyntaxError and report
+// the column of the offending token.
+func (fc *FilterCompiler) Compile(src string) (*Query, error) {
+	toks, err := lexFilter(src)
+	if err != nil {
+		return nil, err
+	}
+	symbols := fc.Symbols
+	if symbols == nil {
+		symbols = DefaultFilterSymbols()
+	}
+	p := &filterParser{src: src, toks: toks, symbols: symbols}
+
+	q := &Query{}
+	if p.peek().kind != filterEOF && !p.peekKeyword("order") {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		groups, err := p.groups(n, false)
+		if err != nil {
+			return nil, err
+		}
+		q.groups = groups
+	}
+	if err := p.parseOrderBy(q); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != filterEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return q, nil
+}
+
+type filterTokenKind int
+
+const (
+	filterEOF filterTokenKind = iota
+	filterIdent
+	filterNumber
+	filterString
+	filterOp
+	filterPunct
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string // identifier, operator or punctuation, or value of a literal
+	pos  int    // byte offset in the source
+}
+
+func (t filterToken) String() string {
+	switch t.kind {
+	case filterEOF:
+		return "end of filter"
+	case filterString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexFilter splits src into tokens.
+func lexFilter(src string) ([]filterToken, error) {
+	var toks []filterToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isFilterIdentStart(c):
+			j := i + 1
+			for j < len(src) && (isFilterIdentStart(src[j]) || isFilterDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, filterToken{kind: filterIdent, text: src[i:j], pos: i})
+			i = j
+		case isFilterDigit(c) || (c == '-' && i+1 < len(src) && isFilterDigit(src[i+1])):
+			j := i + 1
+			for j < len(src) && (isFilterDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, filterToken{kind: filterNumber, text: src[i:j], pos: i})
+			i = j
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, syntaxErrorAt(src, i, "unterminated string")
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, syntaxErrorAt(src, i, "invalid string: "+err.Error())
+			}
+			toks = append(toks, filterToken{kind: filterString, text: s, pos: i})
+			i = j + 1
+		case c == '(' || c == ')' || c == ',':
+			toks = append(toks, filterToken{kind: filterPunct, text: src[i : i+1], pos: i})
+			i++
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			j := i + 1
+			if j < len(src) && (src[j] == '=' || (c == '<' && src[j] == '>')) {
+				j++
+			}
+			op := src[i:j]
+			if op == "!" {
+				return nil, syntaxErrorAt(src, i, `unexpected "!", use != or not`)
+			}
+			toks = append(toks, filterToken{kind: filterOp, text: op, pos: i})
+			i = j
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, syntaxErrorAt(src, i, fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+	return append(toks, filterToken{kind: filterEOF, pos: len(src)}), nil
+}
+
+func isFilterIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isFilterDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// syntaxErrorAt returns a SyntaxError for the byte offset pos of src.
+func syntaxErrorAt(src string, pos int, msg string) *SyntaxError {
+	return &SyntaxError{Input: src, Column: utf8.RuneCountInString(src[:pos]) + 1, Msg: msg}
+}
+
+// filterNode is a node of the syntax tree of a filter: *filterAtom,
+// *filterBinary or *filterNot.
+type filterNode interface{}
+
+type filterAtom struct {
+	cond Condition
+	tok  filterToken // field token, for errors
+}
+
+type filterBinary struct {
+	and         bool // whether the node is an and, rather than an or
+	left, right filterNode
+}
+
+type filterNot struct {
+	x filterNode
+}
+
+type filterParser struct {
+	src     string
+	toks    []filterToken
+	symbols FilterSymbols
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.toks[0]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.toks[0]
+	if t.kind != filterEOF {
+		p.toks = p.toks[1:]
+	}
+	return t
+}
+
+func (p *filterParser) peekKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == filterIdent && strings.EqualFold(t.text, kw)
+}
+
+// acceptKeyword consumes the next token if it is the keyword kw.
+func (p *filterParser) acceptKeyword(kw string) bool {
+	if p.peekKeyword(kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		t := p.peek()
+		return p.errorf(t, "expected %s, found %s", kw, t)
+	}
+	return nil
+}
+
+func (p *filterParser) expectPunct(punct string) error {
+	t := p.next()
+	if t.kind != filterPunct || t.text != punct {
+		return p.errorf(t, "expected %q, found %s", punct, t)
+	}
+	return nil
+}
+
+func (p *filterParser) errorf(t filterToken, format string, args ...interface{}) *SyntaxError {
+	return syntaxErrorAt(p.src, t.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.acceptKeyword("not") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{x: x}, nil
+	}
+	if t := p.peek(); t.kind == filterPunct && t.text == "(" {
+		p.next()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return p.parseCondition()
+}
+
+// filterOps maps the comparison operators of filters to query operators.
+var filterOps = map[string]OperandType{
+	"=":  Eq,
+	"==": Eq,
+	"!=": Ne,
+	"<>": Ne,
+	"<":  Lt,
+	"<=": Le,
+	">":  Gt,
+	">=": Ge,
+}
+
+func (p *filterParser) parseCondition() (filterNode, error) {
+	ft := p.next()
+	if ft.kind != filterIdent {
+		return nil, p.errorf(ft, "expected field, found %s", ft)
+	}
+	if err := validateQueryField(ft.text); err != nil {
+		return nil, p.errorf(ft, "%q is not a valid field name", ft.text)
+	}
+	field := ft.text
+	atom := func(op OperandType, value string) *filterAtom {
+		return &filterAtom{cond: Condition{Field: field, Op: op, Value: value}, tok: ft}
+	}
+
+	if t := p.peek(); t.kind == filterOp {
+		p.next()
+		v, err := p.parseValue(field, "")
+		if err != nil {
+			return nil, err
+		}
+		return atom(filterOps[t.text], v), nil
+	}
+
+	if p.acceptKeyword("is") {
+		op := ISEMPTY
+		if p.acceptKeyword("not") {
+			op = ISNOTEMPTY
+		}
+		if err := p.expectKeyword("empty"); err != nil {
+			return nil, err
+		}
+		return atom(op, ""), nil
+	}
+
+	not := p.acceptKeyword("not")
+	kt := p.next()
+	kw := ""
+	if kt.kind == filterIdent {
+		kw = strings.ToLower(kt.text)
+	}
+	switch kw {
+	case "in":
+		vals, err := p.parseList(field)
+		if err != nil {
+			return nil, err
+		}
+		op := IN
+		if not {
+			op = NOTIN
+		}
+		return atom(op, strings.Join(vals, ",")), nil
+	case "like", "contains":
+		v, err := p.parseValue(field, "")
+		if err != nil {
+			return nil, err
+		}
+		op := LIKE
+		if not {
+			op = NOTLIKE
+		}
+		return atom(op, v), nil
+	case "between":
+		from, err := p.parseValue(field, "@")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("and"); err != nil {
+			return nil, err
+		}
+		to, err := p.parseValue(field, "@")
+		if err != nil {
+			return nil, err
+		}
+		var n filterNode = atom(BETWEEN, from+"@"+to)
+		if not {
+			n = &filterNot{x: n}
+		}
+		return n, nil
+	case "startswith", "endswith":
+		if not {
+			return nil, p.errorf(kt, "%s cannot be negated", kw)
+		}
+		v, err := p.parseValue(field, "")
+		if err != nil {
+			return nil, err
+		}
+		op := STARTSWITH
+		if kw == "endswith" {
+			op = ENDSWITH
+		}
+		return atom(op, v), nil
+	}
+	if not {
+		return nil, p.errorf(kt, "expected in, like or between after not, found %s", kt)
+	}
+	return nil, p.errorf(kt, "expected operator after %s, found %s", field, kt)
+}
+
+// parseList parses a parenthesized list of values.
+func (p *filterParser) parseList(field string) ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var vals []string
+	for {
+		v, err := p.parseValue(field, ",")
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+		t := p.next()
+		if t.kind == filterPunct && t.text == ")" {
+			return vals, nil
+		}
+		if t.kind != filterPunct || t.text != "," {
+			return nil, p.errorf(t, `expected "," or ")", found %s`, t)
+		}
+	}
+}
+
+// filterScripts lists the functions that compile to relative time scripts.
+var filterScripts = map[string]func(int) QueryScript{
+	"daysago":      DaysAgo,
+	"daysagostart": DaysAgoStart,
+	"daysagoend":   DaysAgoEnd,
+	"hoursago":     HoursAgo,
+	"minutesago":   MinutesAgo,
+}
+
+// parseValue parses the value of a condition on field. Values containing
+// sep, which separates the values of some operators, are rejected.
+func (p *filterParser) parseValue(field, sep string) (string, error) {
+	t := p.next()
+	var value interface{}
+	switch t.kind {
+	case filterNumber, filterString:
+		value = t.text
+	case filterIdent:
+		value = t.text
+		if script, ok := filterScripts[strings.ToLower(t.text)]; ok {
+			if err := p.expectPunct("("); err != nil {
+				return "", err
+			}
+			nt := p.next()
+			n, err := strconv.Atoi(nt.text)
+			if nt.kind != filterNumber || err != nil {
+				return "", p.errorf(nt, "expected integer, found %s", nt)
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return "", err
+			}
+			value = script(n)
+			break
+		}
+		if strings.EqualFold(t.text, "true") || strings.EqualFold(t.text, "false") {
+			value = strings.ToLower(t.text)
+			break
+		}
+		if symbols, ok := p.symbols[field]; ok {
+			v, ok := symbols[strings.ToLower(t.text)]
+			if !ok {
+				return "", p.errorf(t, "unknown value %s for %s", t, field)
+			}
+			value = v
+		}
+	default:
+		return "", p.errorf(t, "expected value, found %s", t)
+	}
+
+	v, err := queryValue(field, value)
+	if err != nil {
+		return "", p.errorf(t, "%s", err.(*InvalidQueryError).Reason)
+	}
+	if sep != "" && strings.Contains(v, sep) {
+		return "", p.errorf(t, "value %q contains %s", v, sep)
+	}
+	return v, nil
+}
+
+// parseOrderBy parses the optional order by clause at the end of a filter.
+func (p *filterParser) parseOrderBy(q *Query) error {
+	if !p.acceptKeyword("order") {
+		return nil
+	}
+	if err := p.expectKeyword("by"); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		if t.kind != filterIdent || validateQueryField(t.text) != nil {
+			return p.errorf(t, "expected field, found %s", t)
+		}
+		if p.acceptKeyword("desc") {
+			q.OrderByDesc(t.text)
+		} else {
+			p.acceptKeyword("asc")
+			q.OrderBy(t.text)
+		}
+		if nt := p.peek(); nt.kind != filterPunct || nt.text != "," {
+			return nil
+		}
+		p.next()
+	}
+}
+
+// groups converts n, negated if neg is set, to the ^NQ groups of a query.
+// Disjunctions of single conditions become ^OR clauses, and other
+// disjunctions become separate groups.
+func (p *filterParser) groups(n filterNode, neg bool) ([]queryGroup, error) {
+	switch n := n.(type) {
+	case *filterNot:
+		return p.groups(n.x, !neg)
+	case *filterAtom:
+		clause, err := p.negate(n, neg)
+		if err != nil {
+			return nil, err
+		}
+		return []queryGroup{{clause}}, nil
+	case *filterBinary:
+		left, err := p.groups(n.left, neg)
+		if err != nil {
+			return nil, err
+		}
+		right, err := p.groups(n.right, neg)
+		if err != nil {
+			return nil, err
+		}
+		if n.and != neg {
+			var res []queryGroup
+			for _, l := range left {
+				for _, r := range right {
+					g := append(append(queryGroup(nil), l...), r...)
+					res = append(res, g)
+				}
+			}
+			if len(res) > maxFilterGroups {
+				return nil, syntaxErrorAt(p.src, 0, fmt.Sprintf("filter expands to more than %d alternatives", maxFilterGroups))
+			}
+			return res, nil
+		}
+		if len(left) == 1 && len(left[0]) == 1 && len(right) == 1 && len(right[0]) == 1 {
+			clause := append(append(queryClause(nil), left[0][0]...), right[0][0]...)
+			return []queryGroup{{clause}}, nil
+		}
+		return append(left, right...), nil
+	}
+	panic(fmt.Sprintf("servicenow: unexpected filter node %T", n))
+}
+
+// negate returns the clause for the condition of a, negated if neg is set.
+func (p *filterParser) negate(a *filterAtom, neg bool) (queryClause, error) {
+	c := a.cond
+	if !neg {
+		return queryClause{c}, nil
+	}
+
+	negations := map[OperandType]OperandType{
+		Eq: Ne, Ne: Eq,
+		Lt: Ge, Ge: Lt,
+		Le: Gt, Gt: Le,
+		LIKE: NOTLIKE, NOTLIKE: LIKE,
+		IN: NOTIN, NOTIN: IN,
+		ISEMPTY: ISNOTEMPTY, ISNOTEMPTY: ISEMPTY,
+	}
+	if op, ok := negations[c.Op]; ok {
+		c.Op = op
+		return queryClause{c}, nil
+	}
+	if c.Op == BETWEEN {
+		bounds := strings.SplitN(c.Value, "@", 2)
+		return queryClause{
+			{Field: c.Field, Op: Lt, Value: bounds[0]},
+			{Field: c.Field, Op: Gt, Value: bounds[1]},
+		}, nil
+	}
+	return nil, p.errorf(a.tok, "%s cannot be negated", c.Op)
+}
@@ -0,0 +1,154 @@
+package servicenow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCompileFilter(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"empty", "", ""},
+		{"comparison", "priority <= 2 and state != resolved", "priority<=2^state!=6"},
+		{"string", `assignment_group.name = "SRE team"`, "assignment_group.name=SRE team"},
+		{"keywords", "priority = 1 AND NOT active = TRUE", "priority=1^active!=true"},
+		{"in", "state in (new, resolved) and priority not in (4, 5)", "stateIN1,6^priorityNOT IN4,5"},
+		{"like", `short_description like "disk" and not description contains x`, "short_descriptionLIKEdisk^descriptionNOT LIKEx"},
+		{"startswith", "number startswith INC and number endswith 9", "numberSTARTSWITHINC^numberENDSWITH9"},
+		{"between", "priority between 1 and 3", "priorityBETWEEN1@3"},
+		{"empty", "assigned_to is empty or assigned_to is not empty", "assigned_toISEMPTY^ORassigned_toISNOTEMPTY"},
+		{"relative time", "opened_at > daysAgo(7) and sys_updated_on < hoursAgo(2)", "opened_at>javascript:gs.daysAgo(7)^sys_updated_on<javascript:gs.hoursAgo(2)"},
+		{"order", "active = true order by priority desc, number asc, sys_id", "active=true^ORDERBYDESCpriority^ORDERBYnumber^ORDERBYsys_id"},
+		{"order only", "order by number", "ORDERBYnumber"},
+
+		// and binds tighter than or.
+		{"precedence", "a = 1 or b = 2 and c = 3", "a=1^NQb=2^c=3"},
+		{"precedence left", "a = 1 and b = 2 or c = 3", "a=1^b=2^NQc=3"},
+		{"parentheses", "(a = 1 or b = 2) and c = 3", "a=1^ORb=2^c=3"},
+		{"not binds tightest", "not a = 1 and b = 2", "a!=1^b=2"},
+		{"distribution", "(a = 1 and b = 2 or c = 3) and d = 4", "a=1^b=2^d=4^NQc=3^d=4"},
+
+		// Negation follows De Morgan's laws.
+		{"not between", "not (priority between 1 and 3)", "priority<1^ORpriority>3"},
+		{"not between keyword", "priority not between 1 and 3", "priority<1^ORpriority>3"},
+		{"not or", "not (a = 1 or b = 2)", "a!=1^b!=2"},
+		{"not and", "not (a = 1 and b = 2)", "a!=1^ORb!=2"},
+		{"not comparisons", "not (a < 3 and b >= 5 and c <= 1 and d > 2)", "a>=3^ORb<5^ORc>1^ORd<=2"},
+		{"not in", "not state in (new)", "stateNOT IN1"},
+		{"not empty", "not a is empty", "aISNOTEMPTY"},
+		{"double not", "not not a = 1", "a=1"},
+		{"not nested", "not (a = 1 and not (b = 2 or c = 3))", "a!=1^ORb=2^ORc=3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := CompileFilter(tt.src)
+			if err != nil {
+				t.Fatalf("CompileFilter(%q) returned error: %v", tt.src, err)
+			}
+			got, err := q.Encode()
+			if err != nil {
+				t.Fatalf("Encode returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CompileFilter(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileFilter_syntaxErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{"a = ", 5, "expected value"},
+		{"a = 1 and", 10, "expected field"},
+		{"(a = 1", 7, `expected ")"`},
+		{"a = 1 b", 7, `unexpected "b"`},
+		{"a ! b", 3, "use != or not"},
+		{`a = "x`, 5, "unterminated string"},
+		{"a = #", 5, "unexpected character"},
+		{"a between 1 or 2", 13, "expected and"},
+		{"a in (1 2)", 9, `expected "," or ")"`},
+		{"a = daysAgo(x)", 13, "expected integer"},
+		{"a not startswith x", 7, "cannot be negated"},
+		{"not a startswith x", 5, "cannot be negated"},
+		{"a.b..c = 1", 1, "not a valid field name"},
+		{"a = 1 order number", 13, "expected by"},
+		{`a = "x^NQb = 1"`, 5, "^"},
+		{`a in ("1,2")`, 7, "contains ,"},
+		{`a between "1@2" and 3`, 11, "contains @"},
+		// Columns count characters rather than bytes.
+		{`a = "é" and b ! c`, 15, `unexpected "!"`},
+	}
+	for _, tt := range tests {
+		_, err := CompileFilter(tt.src)
+		var e *SyntaxError
+		if !errors.As(err, &e) {
+			t.Errorf("CompileFilter(%q) returned error %v, want *SyntaxError", tt.src, err)
+			continue
+		}
+		if e.Column != tt.column || !strings.Contains(e.Msg, tt.msg) {
+			t.Errorf("CompileFilter(%q) returned error at column %d: %q, want column %d: %q", tt.src, e.Column, e.Msg, tt.column, tt.msg)
+		}
+	}
+}
+
+func TestFilterCompiler_symbols(t *testing.T) {
+	_, err := CompileFilter("state = bogus")
+	var e *SyntaxError
+	if !errors.As(err, &e) || e.Column != 9 || !strings.Contains(e.Msg, `unknown value "bogus" for state`) {
+		t.Errorf("CompileFilter with an unknown symbol returned error %v", err)
+	}
+
+	// Symbols are case insensitive, and bare words of fields without symbols
+	// are kept as they are.
+	q, err := CompileFilter("state = Resolved and category = Network")
+	if err != nil {
+		t.Fatalf("CompileFilter returned error: %v", err)
+	}
+	if got, want := q.String(), "state=6^category=Network"; got != want {
+		t.Errorf("CompileFilter = %q, want %q", got, want)
+	}
+
+	fc := &FilterCompiler{Symbols: FilterSymbols{"u_tier": {"gold": "1", "silver": "2"}}}
+	q, err = fc.Compile("u_tier in (gold, Silver) and state = resolved")
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	if got, want := q.String(), "u_tierIN1,2^state=resolved"; got != want {
+		t.Errorf("Compile = %q, want %q", got, want)
+	}
+	if _, err := fc.Compile("u_tier = bronze"); err == nil {
+		t.Errorf("Compile with an unknown custom symbol returned no error")
+	}
+}
+
+func TestCompileFilter_maxGroups(t *testing.T) {
+	// Each disjunction doubles the number of ^NQ groups.
+	disjunction := "((a = 1 and b = 2) or (c = 3 and d = 4))"
+	filter := func(n int) string {
+		parts := make([]string, n)
+		for i := range parts {
+			parts[i] = disjunction
+		}
+		return strings.Join(parts, " and ")
+	}
+
+	q, err := CompileFilter(filter(6))
+	if err != nil {
+		t.Fatalf("CompileFilter of %d groups returned error: %v", maxFilterGroups, err)
+	}
+	if got := len(q.groups); got != maxFilterGroups {
+		t.Errorf("CompileFilter returned %d groups, want %d", got, maxFilterGroups)
+	}
+
+	_, err = CompileFilter(filter(7))
+	var e *SyntaxError
+	if !errors.As(err, &e) || !strings.Contains(e.Msg, "alternatives") {
+		t.Errorf("CompileFilter of %d groups returned error %v, want *SyntaxError", 2*maxFilterGroups, err)
+	}
+}
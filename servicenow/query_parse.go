@@ -0,0 +1,178 @@
+package servicenow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// queryOps lists the operators understood by ParseQuery. Operators that are
+// prefixes of others come after them, so that the longest one matches.
+var queryOps = []OperandType{
+	ISNOTEMPTY, ISEMPTY, NOTLIKE, NOTIN, STARTSWITH, ENDSWITH, BETWEEN,
+	LIKE, IN, Ne, Le, Ge, Eq, Lt, Gt,
+}
+
+// SyntaxError reports a malformed encoded query or filter.
+type SyntaxError struct {
+	Input  string // Text that was parsed
+	Column int    // 1-based column of the error in Input
+	Msg    string // Description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Column, e.Msg)
+}
+
+// ParseQuery parses an encoded query, such as the sysparm_query of a
+// ListOptions, into a Query. The String method of the returned Query encodes
+// it back into an equivalent query.
+//
+// ParseQuery understands the operators of the Query builder, ^OR, ^NQ,
+// ORDERBY and ORDERBYDESC. The ^EQ terminator added by list views is ignored.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	offset := 0
+	for i, tok := range strings.Split(s, string(AND)) {
+		if i > 0 {
+			offset++ // the ^ before tok
+		}
+		col := offset + 1
+		offset += len(tok)
+
+		switch {
+		case tok == "" || tok == "EQ":
+			continue
+		case strings.HasPrefix(tok, string(ORDERBYDESC)):
+			tok, col = tok[len(ORDERBYDESC):], col+len(ORDERBYDESC)
+			if err := validateQueryField(tok); err != nil {
+				return nil, &SyntaxError{Input: s, Column: col, Msg: err.Error()}
+			}
+			q.OrderByDesc(tok)
+			continue
+		case strings.HasPrefix(tok, string(ORDERBY)):
+			tok, col = tok[len(ORDERBY):], col+len(ORDERBY)
+			if err := validateQueryField(tok); err != nil {
+				return nil, &SyntaxError{Input: s, Column: col, Msg: err.Error()}
+			}
+			q.OrderBy(tok)
+			continue
+		case strings.HasPrefix(tok, "NQ"):
+			tok, col = tok[len("NQ"):], col+len("NQ")
+			if len(q.groups) == 0 {
+				return nil, &SyntaxError{Input: s, Column: col, Msg: "NQ without preceding condition"}
+			}
+			q.NQ()
+			if tok == "" {
+				continue
+			}
+		case strings.HasPrefix(tok, "OR"):
+			tok, col = tok[len("OR"):], col+len("OR")
+			if len(q.groups) == 0 || q.nq {
+				return nil, &SyntaxError{Input: s, Column: col, Msg: "OR without preceding condition"}
+			}
+			q.Or()
+		}
+
+		c, err := parseCondition(tok)
+		if err != nil {
+			return nil, &SyntaxError{Input: s, Column: col, Msg: err.Error()}
+		}
+		q.add(c)
+	}
+	return q, nil
+}
+
+// parseCondition parses a single condition, such as priority<=2. The field is
+// the shortest valid field name that is followed by an operator.
+func parseCondition(s string) (Condition, error) {
+	for i := 1; i < len(s); i++ {
+		if !queryFieldRE.MatchString(s[:i]) {
+			continue
+		}
+		for _, op := range queryOps {
+			if !strings.HasPrefix(s[i:], string(op)) {
+				continue
+			}
+			c := Condition{Field: s[:i], Op: op, Value: s[i+len(op):]}
+			if (op == ISEMPTY || op == ISNOTEMPTY) && c.Value != "" {
+				return Condition{}, fmt.Errorf("unexpected value %q after %s", c.Value, op)
+			}
+			return c, nil
+		}
+	}
+	return Condition{}, fmt.Errorf("%q is not a condition", s)
+}
+
+// Groups returns the conditions of q. Records match q if they match any of
+// the groups, which are joined by ^NQ. Records match a group if they match all
+// of its clauses, which are joined by ^. Records match a clause if they match
+// any of its conditions, which are joined by ^OR.
+func (q *Query) Groups() [][][]Condition {
+	if q == nil {
+		return nil
+	}
+	groups := make([][][]Condition, len(q.groups))
+	for i, g := range q.groups {
+		groups[i] = make([][]Condition, len(g))
+		for j, clause := range g {
+			groups[i][j] = append([]Condition(nil), clause...)
+		}
+	}
+	return groups
+}
+
+// Pretty returns q in a readable form, with one clause per line:
+//
+//	active = true
+//	AND (priority = 1 OR priority = 2)
+//	NQ
+//	assigned_to ISEMPTY
+//	ORDER BY sys_created_on DESC
+func (q *Query) Pretty() string {
+	if q == nil {
+		return ""
+	}
+
+	var lines []string
+	for i, g := range q.groups {
+		if i > 0 {
+			lines = append(lines, "NQ")
+		}
+		for j, clause := range g {
+			alts := make([]string, len(clause))
+			for k, c := range clause {
+				alts[k] = c.pretty()
+			}
+			line := strings.Join(alts, " OR ")
+			if len(clause) > 1 {
+				line = "(" + line + ")"
+			}
+			if j > 0 {
+				line = "AND " + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	for _, o := range q.orderBy {
+		if strings.HasPrefix(o, string(ORDERBYDESC)) {
+			lines = append(lines, "ORDER BY "+o[len(ORDERBYDESC):]+" DESC")
+		} else {
+			lines = append(lines, "ORDER BY "+o[len(ORDERBY):])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// pretty returns c with spaces around its operator, quoting empty values and
+// values with surrounding spaces.
+func (c Condition) pretty() string {
+	if c.Op == ISEMPTY || c.Op == ISNOTEMPTY {
+		return c.Field + " " + string(c.Op)
+	}
+	v := c.Value
+	if v == "" || strings.TrimSpace(v) != v {
+		v = strconv.Quote(v)
+	}
+	return c.Field + " " + string(c.Op) + " " + v
+}
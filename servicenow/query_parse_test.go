@@ -0,0 +1,130 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// roundTripQueries are encoded queries that ParseQuery parses and String
+// encodes back unchanged.
+var roundTripQueries = []string{
+	"",
+	"number=INC0000001",
+	"active=true^priority<=2^state!=6",
+	"priority=1^ORpriority=2^active=true",
+	"active=true^NQassigned_toISEMPTY^ORpriority=1",
+	"stateIN1,2,3^categoryNOT INhardware,network",
+	"short_descriptionLIKEdisk^descriptionNOT LIKEtest^numberSTARTSWITHINC^numberENDSWITH1",
+	"priorityBETWEEN1@3^assigned_toISNOTEMPTY",
+	"sys_created_on>javascript:gs.daysAgo(7)",
+	"opened_at>=2024-01-02 03:04:05^opened_at<2024-02-01 00:00:00",
+	"assignment_group.name=SRE",
+	"active=true^ORDERBYpriority^ORDERBYDESCsys_created_on",
+	"ORDERBYnumber",
+}
+
+func TestParseQuery_roundTrip(t *testing.T) {
+	for _, s := range roundTripQueries {
+		q, err := ParseQuery(s)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) returned error: %v", s, err)
+			continue
+		}
+		if got, err := q.Encode(); err != nil || got != s {
+			t.Errorf("ParseQuery(%q).Encode() = %q, %v, want %q", s, got, err, s)
+		}
+	}
+}
+
+func TestParseQuery_builder(t *testing.T) {
+	built := NewQuery().
+		Eq("active", true).
+		Eq("priority", 1).Or().Eq("priority", 2).
+		NQ().
+		IsEmpty("assigned_to").
+		OrderByDesc("sys_created_on")
+	parsed, err := ParseQuery(built.String())
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+	if got, want := len(parsed.Groups()), 2; got != want {
+		t.Errorf("ParseQuery returned %d groups, want %d", got, want)
+	}
+	if got, want := parsed.String(), built.String(); got != want {
+		t.Errorf("ParseQuery(%q).String() = %q", want, got)
+	}
+}
+
+func TestParseQuery_ignoresEQ(t *testing.T) {
+	q, err := ParseQuery("active=true^EQ")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+	if got, want := q.String(), "active=true"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+func TestParseQuery_syntaxErrors(t *testing.T) {
+	tests := []struct {
+		in     string
+		column int
+	}{
+		{"active", 1},
+		{"active=true^^ORpriority=1^bogus", 27},
+		{"ORpriority=1", 3},
+		{"NQactive=true", 3},
+		{"active=true^ORDERBY", 20},
+		{"assigned_toISEMPTYx", 1},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.in)
+		var e *SyntaxError
+		if !errors.As(err, &e) {
+			t.Errorf("ParseQuery(%q) returned error %v, want *SyntaxError", tt.in, err)
+			continue
+		}
+		if e.Column != tt.column {
+			t.Errorf("ParseQuery(%q) error column = %d, want %d (%v)", tt.in, e.Column, tt.column, err)
+		}
+	}
+}
+
+// TestParseQuery_listOptions checks that a parsed query is sent unchanged as
+// the sysparm_query of List, alone and combined with QueryOpts.
+func TestParseQuery_listOptions(t *testing.T) {
+	client, mux := setup(t)
+	var got []string
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("sysparm_query"))
+		writeJSON(t, w, records())
+	})
+
+	for _, s := range roundTripQueries {
+		got = nil
+		q, err := ParseQuery(s)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) returned error: %v", s, err)
+		}
+		if _, _, err := client.Incidents.List(context.Background(), ListOptions{Query: q}); err != nil {
+			t.Errorf("List(%q) returned error: %v", s, err)
+			continue
+		}
+		if len(got) != 1 || got[0] != s {
+			t.Errorf("List(%q) sent sysparm_query %q", s, got)
+		}
+	}
+
+	got = nil
+	q, _ := ParseQuery("priority=1^ORpriority=2^NQassigned_toISEMPTY^ORDERBYnumber")
+	opts := ListOptions{Query: q, QueryOpts: []QueryOpts{{Key: "active", Op: Eq, Val: "true"}}}
+	if _, _, err := client.Incidents.List(context.Background(), opts); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	want := "priority=1^ORpriority=2^active=true^NQassigned_toISEMPTY^active=true^ORDERBYnumber"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("List sent sysparm_query %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// hostileValues are values that would change the structure of an encoded
+// query, and so select other records, if they were used as is.
+var hostileValues = []string{
+	"INC0000001^ORnumber!=INC0000001",
+	"INC0000001^NQactive=true",
+	"^ORactive=true",
+	"^NQ",
+	"^",
+	"javascript:gs.getUserID()",
+	"  JavaScript:gs.getUserID()",
+}
+
+func TestQuery_Encode(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *Query
+		want string
+	}{
+		{"empty", NewQuery(), ""},
+		{"eq", NewQuery().Eq("number", "INC0000001"), "number=INC0000001"},
+		{"and or", NewQuery().Eq("active", true).Eq("priority", 1).Or().Eq("priority", 2), "active=true^priority=1^ORpriority=2"},
+		{"nq", NewQuery().Eq("a", "1").NQ().IsEmpty("b"), "a=1^NQbISEMPTY"},
+		{"in", NewQuery().In("state", 1, 2, 3), "stateIN1,2,3"},
+		{"between", NewQuery().Between("priority", 1, 3), "priorityBETWEEN1@3"},
+		{"script", NewQuery().Gt("sys_created_on", DaysAgo(7)), "sys_created_on>javascript:gs.daysAgo(7)"},
+		{"time", NewQuery().Ge("opened_at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "opened_at>=2024-01-02 03:04:05"},
+		{"choice", NewQuery().Eq("state", IncidentStateResolved), "state=6"},
+		{"order", NewQuery().Eq("a", "1").OrderByDesc("sys_created_on"), "a=1^ORDERBYDESCsys_created_on"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Encode()
+			if err != nil {
+				t.Fatalf("Encode returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_hostileValues(t *testing.T) {
+	type build func(q *Query, v string) *Query
+	builds := map[string]build{
+		"Eq":         func(q *Query, v string) *Query { return q.Eq("number", v) },
+		"Ne":         func(q *Query, v string) *Query { return q.Ne("number", v) },
+		"Like":       func(q *Query, v string) *Query { return q.Like("short_description", v) },
+		"StartsWith": func(q *Query, v string) *Query { return q.StartsWith("number", v) },
+		"In":         func(q *Query, v string) *Query { return q.In("number", "INC1", v) },
+		"NotIn":      func(q *Query, v string) *Query { return q.NotIn("number", v) },
+		"Between":    func(q *Query, v string) *Query { return q.Between("number", v, "INC9") },
+		"Or":         func(q *Query, v string) *Query { return q.Eq("a", "1").Or().Eq("number", v) },
+		"NQ":         func(q *Query, v string) *Query { return q.Eq("a", "1").NQ().Eq("number", v) },
+	}
+	for name, build := range builds {
+		for _, v := range hostileValues {
+			t.Run(name+"/"+v, func(t *testing.T) {
+				q := build(NewQuery(), v)
+				_, err := q.Encode()
+				var e *InvalidQueryError
+				if !errors.As(err, &e) {
+					t.Fatalf("Encode returned error %v, want *InvalidQueryError", err)
+				}
+			})
+		}
+	}
+}
+
+func TestQuery_hostileListValues(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *Query
+	}{
+		{"comma in In", NewQuery().In("number", "INC1,INC2")},
+		{"comma in NotIn", NewQuery().NotIn("number", "INC1", "INC2,INC3")},
+		{"at in Between from", NewQuery().Between("number", "INC1@INC9", "INC2")},
+		{"at in Between to", NewQuery().Between("number", "INC1", "INC2@INC9")},
+		{"script in Between", NewQuery().Between("number", "javascript:gs.getUserID()", "INC2")},
+		{"caret in script", NewQuery().Eq("number", QueryScript("javascript:x()^ORactive=true"))},
+		{"bad field", NewQuery().Eq("number^ORactive", "1")},
+		{"bad order field", NewQuery().OrderBy("number^ORactive=true")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.q.Encode()
+			var e *InvalidQueryError
+			if !errors.As(err, &e) {
+				t.Fatalf("Encode returned error %v, want *InvalidQueryError", err)
+			}
+		})
+	}
+}
+
+func TestQuery_errorKeepsFirst(t *testing.T) {
+	q := NewQuery().Eq("number", "INC1^NQ").Eq("active", "javascript:x()")
+	_, err := q.Encode()
+	var e *InvalidQueryError
+	if !errors.As(err, &e) || e.Value != "INC1^NQ" {
+		t.Errorf("Encode returned error %v, want the error of the first invalid condition", err)
+	}
+	if got := q.String(); got != "" {
+		t.Errorf("String = %q, want invalid conditions left out", got)
+	}
+}
+
+// failHandler fails the test if a request reaches it.
+func failHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %v %v", r.Method, r.URL)
+	}
+}
+
+func TestTableService_hostileNumbers(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		mux.HandleFunc("/", failHandler(t))
+		ctx := context.Background()
+
+		for _, v := range hostileValues {
+			if _, _, err := client.Incidents.Get(ctx, v, GetOptions{}); err == nil {
+				t.Errorf("backend %v: Get(%q) returned no error", backend, v)
+			}
+			if _, _, err := client.Incidents.Update(ctx, v, &Incident{}, UpdateOptions{}); err == nil {
+				t.Errorf("backend %v: Update(%q) returned no error", backend, v)
+			}
+			if _, err := client.Incidents.Delete(ctx, v); err == nil {
+				t.Errorf("backend %v: Delete(%q) returned no error", backend, v)
+			}
+			if _, _, err := client.Incidents.List(ctx, ListOptions{Query: NewQuery().Eq("number", v)}); err == nil {
+				t.Errorf("backend %v: List with Query value %q returned no error", backend, v)
+			}
+			if _, _, err := client.Incidents.List(ctx, ListOptions{QueryOpts: []QueryOpts{{Key: "number", Op: Eq, Val: v}}}); err == nil {
+				t.Errorf("backend %v: List with QueryOpts value %q returned no error", backend, v)
+			}
+		}
+		if _, _, err := client.Incidents.List(ctx, ListOptions{QueryOpts: []QueryOpts{{Key: "number", Op: "=1^ORactive", Val: "true"}}}); err == nil {
+			t.Errorf("backend %v: List with hostile QueryOpts operator returned no error", backend)
+		}
+		if _, _, err := client.Incidents.List(ctx, ListOptions{QueryOpts: []QueryOpts{{Key: "number^NQactive", Op: Eq, Val: "true"}}}); err == nil {
+			t.Errorf("backend %v: List with hostile QueryOpts field returned no error", backend)
+		}
+	}
+}
+
+func TestTableService_sentQueries(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		ctx := context.Background()
+
+		var want string
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case backend == BackendTable && r.Method == "PATCH":
+				writeJSON(t, w, result(map[string]interface{}{"sys_id": "s1", "number": "INC1"}))
+				return
+			case backend == BackendTable && r.URL.Query().Get("sysparm_fields") == "sys_id":
+				// sys_id lookup made by Update on the Table API.
+				testQuery(t, r, want)
+				writeJSON(t, w, result([]map[string]interface{}{{"sys_id": "s1"}}))
+				return
+			}
+			testQuery(t, r, want)
+			rec := map[string]interface{}{"sys_id": "s1", "number": "INC1"}
+			if backend == BackendTable {
+				writeJSON(t, w, result([]map[string]interface{}{rec}))
+			} else {
+				writeJSON(t, w, records(rec))
+			}
+		}
+		mux.HandleFunc("/incident.do", handler)
+		mux.HandleFunc("/api/now/table/incident", handler)
+		mux.HandleFunc("/api/now/table/incident/s1", handler)
+
+		want = "number=INC1"
+		if _, _, err := client.Incidents.Get(ctx, "INC1", GetOptions{}); err != nil {
+			t.Errorf("backend %v: Get returned error: %v", backend, err)
+		}
+		if _, _, err := client.Incidents.Update(ctx, "INC1", &Incident{Description: NewField("x")}, UpdateOptions{}); err != nil {
+			t.Errorf("backend %v: Update returned error: %v", backend, err)
+		}
+
+		// Values that merely contain query syntax without ^ are sent as is.
+		want = "short_description=a=b^ORDERBYnumber"
+		if _, _, err := client.Incidents.List(ctx, ListOptions{Query: NewQuery().Eq("short_description", "a=b").OrderBy("number")}); err != nil {
+			t.Errorf("backend %v: List returned error: %v", backend, err)
+		}
+		want = "active=true^priority=1"
+		opts := ListOptions{Query: NewQuery().Eq("active", true), QueryOpts: []QueryOpts{{Key: "priority", Op: Eq, Val: "1"}}}
+		if _, _, err := client.Incidents.List(ctx, opts); err != nil {
+			t.Errorf("backend %v: List returned error: %v", backend, err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// recordFieldNames caches the lower case JSON names of the fields of record
+// types, by type.
+var recordFieldNames sync.Map // map[reflect.Type]map[string]bool
+
+// fieldNames returns the lower case JSON names of the fields of the struct
+// type t, including the fields promoted from the structs it embeds.
+func fieldNames(t reflect.Type) map[string]bool {
+	if names, ok := recordFieldNames.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for n := range fieldNames(ft) {
+				names[n] = true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	recordFieldNames.Store(t, names)
+	return names
+}
+
+// MarshalRecord returns the JSON encoding of record, which is typically a
+// pointer to a record struct such as Incident, or to a struct that embeds
+// one. The fields in the Extra map of record, or of the struct it embeds, are
+// added to those of the struct.
+//
+// Record structs have no MarshalJSON method, so that structs embedding them
+// are encoded with all their fields; TableService encodes records with
+// MarshalRecord.
+func MarshalRecord(record interface{}) ([]byte, error) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	extra, ok := recordExtra(reflect.ValueOf(record), false)
+	if !ok || extra.Len() == 0 {
+		return b, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range extra.Interface().(map[string]json.RawMessage) {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalRecord decodes the JSON object data into record, which must be a
+// pointer. If record is a pointer to a struct with an Extra map, or to a
+// struct embedding one, the fields of data without a corresponding struct
+// field, such as custom u_ fields, are stored in Extra.
+func UnmarshalRecord(data []byte, record interface{}) error {
+	if err := json.Unmarshal(data, record); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(record)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if _, ok := extraField(v.Elem().Type()); !ok {
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	names := fieldNames(v.Elem().Type())
+	var extra map[string]json.RawMessage
+	for k, raw := range m {
+		if names[strings.ToLower(k)] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = raw
+	}
+	if extra != nil {
+		field, _ := recordExtra(v, true)
+		field.Set(reflect.ValueOf(extra))
+	}
+	return nil
+}
+
+// extraType is the type of the Extra field of records.
+var extraType = reflect.TypeOf(map[string]json.RawMessage(nil))
+
+// recordExtra returns the Extra field of the struct v points to, or of the
+// structs it embeds, and reports whether there is one. Nil embedded pointers
+// are allocated if alloc is set, and skipped otherwise.
+func recordExtra(v reflect.Value, alloc bool) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	f, ok := extraField(v.Type())
+	if !ok {
+		return reflect.Value{}, false
+	}
+	field, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		if !alloc {
+			return reflect.Value{}, false
+		}
+		// Allocate the nil embedded pointers on the path to Extra.
+		field = v
+		for _, i := range f.Index {
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				field = field.Elem()
+			}
+			field = field.Field(i)
+		}
+	}
+	return field, true
+}
+
+// extraField returns the Extra field of the struct type t, which may be
+// promoted from a struct it embeds, and reports whether there is one.
+func extraField(t reflect.Type) (reflect.StructField, bool) {
+	f, ok := t.FieldByName("Extra")
+	if !ok || f.Type != extraType || f.Tag.Get("json") != "-" {
+		return reflect.StructField{}, false
+	}
+	return f, true
+}
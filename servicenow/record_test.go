@@ -0,0 +1,138 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const recordJSON = `{"number":"INC1","u_team":"sre","u_other":1}`
+
+func TestIncident_record(t *testing.T) {
+	var inc Incident
+	if err := UnmarshalRecord([]byte(recordJSON), &inc); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	want := map[string]json.RawMessage{"u_team": json.RawMessage(`"sre"`), "u_other": json.RawMessage(`1`)}
+	if inc.GetNumber() != "INC1" || !reflect.DeepEqual(inc.Extra, want) {
+		t.Errorf("UnmarshalRecord = %v, Extra %v, want INC1 and Extra %v", inc, inc.Extra, want)
+	}
+
+	for _, v := range []interface{}{inc, &inc} {
+		b, err := MarshalRecord(v)
+		if err != nil {
+			t.Fatalf("MarshalRecord returned error: %v", err)
+		}
+		if got := string(b); got != `{"number":"INC1","u_other":1,"u_team":"sre"}` {
+			t.Errorf("MarshalRecord(%T) = %s", v, got)
+		}
+	}
+}
+
+type teamIncident struct {
+	Incident
+	Team *Field[string] `json:"u_team,omitempty"`
+}
+
+type teamIncidentPtr struct {
+	*Incident
+	Team *Field[string] `json:"u_team,omitempty"`
+}
+
+// TeamIncident is exported so that it can be embedded.
+type TeamIncident = teamIncident
+
+type nestedTeamIncident struct {
+	TeamIncident
+	Note string `json:"u_note,omitempty"`
+}
+
+func TestRecord_embedded(t *testing.T) {
+	wantExtra := map[string]json.RawMessage{"u_other": json.RawMessage(`1`)}
+	const wantJSON = `{"number":"INC1","u_other":1,"u_team":"sre"}`
+
+	var inc teamIncident
+	if err := UnmarshalRecord([]byte(recordJSON), &inc); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	if inc.GetNumber() != "INC1" || inc.Team.Get() != "sre" || !reflect.DeepEqual(inc.Extra, wantExtra) {
+		t.Errorf("UnmarshalRecord = %+v, Extra %v", inc, inc.Extra)
+	}
+	if b, err := MarshalRecord(&inc); err != nil || string(b) != wantJSON {
+		t.Errorf("MarshalRecord = %s, %v, want %s", b, err, wantJSON)
+	}
+
+	var ptr teamIncidentPtr
+	if err := UnmarshalRecord([]byte(recordJSON), &ptr); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	if ptr.Incident == nil || ptr.GetNumber() != "INC1" || ptr.Team.Get() != "sre" || !reflect.DeepEqual(ptr.Extra, wantExtra) {
+		t.Errorf("UnmarshalRecord = %+v", ptr)
+	}
+	if b, err := MarshalRecord(&ptr); err != nil || string(b) != wantJSON {
+		t.Errorf("MarshalRecord = %s, %v, want %s", b, err, wantJSON)
+	}
+	if b, err := MarshalRecord(&teamIncidentPtr{Team: NewField("sre")}); err != nil || string(b) != `{"u_team":"sre"}` {
+		t.Errorf("MarshalRecord with a nil embedded pointer = %s, %v", b, err)
+	}
+
+	var nested nestedTeamIncident
+	if err := UnmarshalRecord([]byte(`{"number":"INC1","u_team":"sre","u_note":"n"}`), &nested); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	if nested.GetNumber() != "INC1" || nested.Team.Get() != "sre" || nested.Note != "n" || nested.Extra != nil {
+		t.Errorf("UnmarshalRecord = %+v, Extra %v", nested, nested.Extra)
+	}
+	if b, err := MarshalRecord(nested); err != nil || string(b) != `{"number":"INC1","u_team":"sre","u_note":"n"}` {
+		t.Errorf("MarshalRecord = %s, %v", b, err)
+	}
+}
+
+func TestUnmarshalRecord_keepsUnsetFields(t *testing.T) {
+	inc := teamIncident{Incident: Incident{Description: NewField("d")}, Team: NewField("ops")}
+	if err := UnmarshalRecord([]byte(`{"number":"INC1"}`), &inc); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	if inc.GetNumber() != "INC1" || inc.GetDescription() != "d" || inc.Team.Get() != "ops" {
+		t.Errorf("UnmarshalRecord = %+v, want fields absent from the data kept", inc)
+	}
+}
+
+// Record structs have no JSON methods, so encoding/json encodes and decodes
+// the fields of the structs embedding them as well.
+func TestRecord_embeddedJSON(t *testing.T) {
+	var inc teamIncident
+	if err := json.Unmarshal([]byte(recordJSON), &inc); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if inc.GetNumber() != "INC1" || inc.Team.Get() != "sre" {
+		t.Errorf("Unmarshal = %+v, want INC1 and team sre", inc)
+	}
+
+	for _, v := range []interface{}{inc, &inc, &teamIncidentPtr{Incident: &inc.Incident, Team: inc.Team}} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if got := string(b); got != `{"number":"INC1","u_team":"sre"}` {
+			t.Errorf("Marshal(%T) = %s", v, got)
+		}
+	}
+}
+
+type unexportedEmbedding struct {
+	teamIncident
+}
+
+func TestRecord_unexportedEmbedding(t *testing.T) {
+	var inc unexportedEmbedding
+	if err := UnmarshalRecord([]byte(recordJSON), &inc); err != nil {
+		t.Fatalf("UnmarshalRecord returned error: %v", err)
+	}
+	if inc.GetNumber() != "INC1" || inc.Team.Get() != "sre" {
+		t.Errorf("UnmarshalRecord = %+v", inc)
+	}
+	if b, err := MarshalRecord(&inc); err != nil || string(b) != `{"number":"INC1","u_other":1,"u_team":"sre"}` {
+		t.Errorf("MarshalRecord = %s, %v", b, err)
+	}
+}
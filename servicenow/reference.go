@@ -0,0 +1,157 @@
+package servicenow
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Reference is the value of a reference field, such as assigned_to, which
+// holds the sys_id of a record in another table.
+//
+// Reference fields are returned as bare sys_ids, or as display values with
+// DisplayValue "true", by the JSONv2 processor, and as objects holding the
+// sys_id and the API link of the referenced record by the Table API. Reference
+// decodes from either form; the records services store a bare string in
+// DisplayValue rather than Value if display values were requested. It encodes
+// as its Value or, if Value is nil, its DisplayValue, which is what the
+// instance expects when writing records.
+type Reference struct {
+	Value        *string `json:"value,omitempty"`         // sys_id of the referenced record
+	Link         *string `json:"link,omitempty"`          // API URL of the referenced record
+	DisplayValue *string `json:"display_value,omitempty"` // Display value of the referenced record
+}
+
+// NewReference returns a Reference to the record sysID.
+func NewReference(sysID string) *Reference {
+	return &Reference{Value: &sysID}
+}
+
+func (r Reference) String() string {
+	return Stringify(r)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A bare string is
+// stored in Value.
+func (r *Reference) UnmarshalJSON(data []byte) error {
+	if s, ok, err := unmarshalBareString(data); ok || err != nil {
+		*r = Reference{Value: s}
+		return err
+	}
+	type reference Reference
+	var v reference
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = Reference(v)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Reference) MarshalJSON() ([]byte, error) {
+	return marshalPairValue(r.Value, r.DisplayValue)
+}
+
+// fromDisplayValue moves a bare string decoded into Value to DisplayValue.
+func (r *Reference) fromDisplayValue() {
+	r.Value, r.DisplayValue = bareDisplayValue(r.Value, r.DisplayValue, r.Link)
+}
+
+// DisplayValuePair is the value of a field returned with DisplayValue "all",
+// which returns both the value and the display value of each field, such as
+// 6 and Resolved for the state of a resolved incident.
+//
+// DisplayValuePair decodes from either an object holding both values or a
+// bare string, which is stored in Value. It encodes as its Value or, if Value
+// is nil, its DisplayValue.
+type DisplayValuePair struct {
+	Value        *string `json:"value,omitempty"`
+	DisplayValue *string `json:"display_value,omitempty"`
+}
+
+func (p DisplayValuePair) String() string {
+	return Stringify(p)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *DisplayValuePair) UnmarshalJSON(data []byte) error {
+	if s, ok, err := unmarshalBareString(data); ok || err != nil {
+		*p = DisplayValuePair{Value: s}
+		return err
+	}
+	type displayValuePair DisplayValuePair
+	var v displayValuePair
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*p = DisplayValuePair(v)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p DisplayValuePair) MarshalJSON() ([]byte, error) {
+	return marshalPairValue(p.Value, p.DisplayValue)
+}
+
+// fromDisplayValue moves a bare string decoded into Value to DisplayValue.
+func (p *DisplayValuePair) fromDisplayValue() {
+	p.Value, p.DisplayValue = bareDisplayValue(p.Value, p.DisplayValue, nil)
+}
+
+// displayValuer is implemented by values that decode a bare string into
+// their Value, though it is a display value if display values were requested.
+type displayValuer interface {
+	fromDisplayValue()
+}
+
+// fromDisplayValues moves the bare strings decoded into the References and
+// DisplayValuePairs reachable from v, which is typically a pointer to a slice
+// of records decoded from display values, to their DisplayValue.
+func fromDisplayValues(v reflect.Value) {
+	visitValues(v, func(v reflect.Value) bool {
+		d, ok := v.Addr().Interface().(displayValuer)
+		if ok {
+			d.fromDisplayValue()
+		}
+		return ok
+	})
+}
+
+// bareDisplayValue returns the value and display value of a field decoded
+// from a display value. Only a bare string, which sets value alone, is
+// moved to the display value.
+func bareDisplayValue(value, displayValue, link *string) (*string, *string) {
+	if value == nil || displayValue != nil || link != nil {
+		return value, displayValue
+	}
+	return nil, value
+}
+
+// unmarshalBareString decodes data if it is a JSON string or null, and
+// reports whether it was.
+func unmarshalBareString(data []byte) (*string, bool, error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil, true, nil
+	}
+	if len(data) == 0 || data[0] != '"' {
+		return nil, false, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, true, err
+	}
+	return &s, true, nil
+}
+
+// marshalPairValue encodes value or, if it is nil, displayValue, as the
+// value written to a field.
+func marshalPairValue(value, displayValue *string) ([]byte, error) {
+	switch {
+	case value != nil:
+		return json.Marshal(*value)
+	case displayValue != nil:
+		return json.Marshal(*displayValue)
+	}
+	return []byte(`""`), nil
+}
@@ -0,0 +1,213 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultMinBackoff  = 500 * time.Millisecond
+	defaultMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy configures how a Client retries requests that failed with a
+// transient error. Retries are disabled unless Client.RetryPolicy is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// It defaults to 3.
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the jittered exponential backoff between
+	// attempts. They default to 500ms and 30s. A Retry-After header sent by the
+	// instance takes precedence over the computed backoff, but is capped at
+	// MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Retryable reports whether a request that received resp should be
+	// retried. It defaults to DefaultRetryable.
+	Retryable func(resp *http.Response) bool
+
+	// RetryableError reports whether a request that failed with the transport
+	// error err should be retried. It defaults to DefaultRetryableError.
+	RetryableError func(err error) bool
+
+	// RetryInserts allows retrying requests that insert records. Inserts are
+	// not idempotent: if a failed attempt reached the instance, retrying it
+	// creates a duplicate record.
+	RetryInserts bool
+}
+
+// DefaultRetryable reports whether resp has a status code that indicates a
+// transient failure: 429 Too Many Requests, 502 Bad Gateway, 503 Service
+// Unavailable or 504 Gateway Timeout.
+func DefaultRetryable(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// DefaultRetryableError reports whether err is a transport error that
+// indicates a transient failure: a timeout, or a connection that was refused,
+// reset or closed before the response was received. Other errors, such as TLS
+// handshake failures or invalid URLs, are not retried.
+func DefaultRetryableError(err error) bool {
+	switch {
+	case errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	var e net.Error
+	return errors.As(err, &e) && e.Timeout()
+}
+
+// retryWait reports whether req should be attempted again after attempt
+// failed with resp or, if resp is nil, with the transport error err, and how
+// long to wait before doing so.
+func (p *RetryPolicy) retryWait(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.maxAttempts() {
+		return 0, false
+	}
+	if !p.RetryInserts && !idempotent(req) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false // the body has been consumed and cannot be replayed
+	}
+	if resp != nil {
+		retryable := p.Retryable
+		if retryable == nil {
+			retryable = DefaultRetryable
+		}
+		if !retryable(resp) {
+			return 0, false
+		}
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if max := p.maxBackoff(); d > max {
+				d = max
+			}
+			return d, true
+		}
+	} else {
+		retryable := p.RetryableError
+		if retryable == nil {
+			retryable = DefaultRetryableError
+		}
+		if !retryable(err) {
+			return 0, false
+		}
+	}
+	return p.backoff(attempt), true
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return defaultMaxBackoff
+}
+
+// backoff returns the jittered exponential backoff to wait after attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	lo, hi := p.MinBackoff, p.maxBackoff()
+	if lo <= 0 {
+		lo = defaultMinBackoff
+	}
+	d := lo
+	for i := 1; i < attempt && d < hi; i++ {
+		d *= 2
+	}
+	if d > hi {
+		d = hi
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// idempotentActions lists the sysparm_action values that may be safely
+// replayed when sent with a POST request.
+var idempotentActions = map[string]bool{
+	SysparmActionUpdate:         true,
+	SysparmActionDelete:         true,
+	SysparmActionDeleteMultiple: true,
+}
+
+// idempotent reports whether req can be replayed without side effects beyond
+// those of the first attempt. POST requests insert records unless their
+// sysparm_action says otherwise.
+func idempotent(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+	return idempotentActions[req.URL.Query().Get("sysparm_action")]
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either in
+// seconds or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// prepareRetry waits for d, or until ctx is done, and rewinds the body of req
+// so that it can be sent again.
+func prepareRetry(ctx context.Context, req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+	}
+	return nil
+}
+
+// discardBody drains and closes the body of a response that is about to be
+// retried, so that the underlying connection can be reused.
+func discardBody(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,298 @@
+package servicenow
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fastRetries is a RetryPolicy that does not slow tests down.
+func fastRetries() *RetryPolicy {
+	return &RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+// flakyHandler responds with status to the first failures requests, and
+// with an empty JSONv2 envelope afterwards. It records the bodies it receives.
+type flakyHandler struct {
+	t        *testing.T
+	failures int
+	status   int
+	header   http.Header
+
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.t.Errorf("reading body: %v", err)
+	}
+	h.mu.Lock()
+	h.bodies = append(h.bodies, string(body))
+	n := len(h.bodies)
+	h.mu.Unlock()
+
+	if n <= h.failures {
+		for k, v := range h.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(h.status)
+		return
+	}
+	writeJSON(h.t, w, records())
+}
+
+func (h *flakyHandler) attempts() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.bodies)
+}
+
+func TestBareDo_retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *RetryPolicy
+		method   string
+		url      string
+		failures int
+		status   int
+		want     int // attempts
+		wantErr  bool
+	}{
+		{"disabled", nil, "GET", "incident.do", 1, http.StatusServiceUnavailable, 1, true},
+		{"transient", fastRetries(), "GET", "incident.do", 2, http.StatusServiceUnavailable, 3, false},
+		{"too many requests", fastRetries(), "GET", "incident.do", 1, http.StatusTooManyRequests, 2, false},
+		{"max attempts", fastRetries(), "GET", "incident.do", 5, http.StatusBadGateway, 3, true},
+		{"not transient", fastRetries(), "GET", "incident.do", 1, http.StatusInternalServerError, 1, true},
+		{"update", fastRetries(), "POST", "incident.do?sysparm_action=update", 1, http.StatusServiceUnavailable, 2, false},
+		{"insert", fastRetries(), "POST", "incident.do?sysparm_action=insert", 1, http.StatusServiceUnavailable, 1, true},
+		{"insert without action", fastRetries(), "POST", "api/now/table/incident", 1, http.StatusServiceUnavailable, 1, true},
+		{"retry inserts", &RetryPolicy{MinBackoff: time.Millisecond, RetryInserts: true}, "POST", "incident.do?sysparm_action=insert", 1, http.StatusServiceUnavailable, 2, false},
+		{
+			name:     "custom retryable",
+			policy:   &RetryPolicy{MinBackoff: time.Millisecond, Retryable: func(resp *http.Response) bool { return resp.StatusCode == http.StatusInternalServerError }},
+			method:   "GET",
+			url:      "incident.do",
+			failures: 1,
+			status:   http.StatusInternalServerError,
+			want:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			client.RetryPolicy = tt.policy
+			h := &flakyHandler{t: t, failures: tt.failures, status: tt.status}
+			mux.Handle("/incident.do", h)
+			mux.Handle("/api/now/table/incident", h)
+
+			var body interface{}
+			if tt.method == "POST" {
+				body = map[string]string{"short_description": "retried"}
+			}
+			req, err := client.NewRequest(tt.method, tt.url, body)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			_, err = client.Do(context.Background(), req, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Do returned error %v, want error %v", err, tt.wantErr)
+			}
+			if got := h.attempts(); got != tt.want {
+				t.Errorf("attempts = %d, want %d", got, tt.want)
+			}
+			// Every attempt sends the whole body, replayed through GetBody.
+			for i, b := range h.bodies {
+				if b != h.bodies[0] {
+					t.Errorf("body of attempt %d = %q, want %q", i+1, b, h.bodies[0])
+				}
+			}
+			if tt.method == "POST" && h.bodies[0] == "" {
+				t.Errorf("body of the first attempt is empty")
+			}
+		})
+	}
+}
+
+func TestBareDo_retryUnreplayableBody(t *testing.T) {
+	client, mux := setup(t)
+	client.RetryPolicy = fastRetries()
+	h := &flakyHandler{t: t, failures: 1, status: http.StatusServiceUnavailable}
+	mux.Handle("/incident.do", h)
+
+	req, err := client.NewUploadRequest("incident.do?sysparm_action=update", io.NopCloser(io.LimitReader(zeros{}, 10)), 10, "")
+	if err != nil {
+		t.Fatalf("NewUploadRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Errorf("Do returned no error")
+	}
+	if got := h.attempts(); got != 1 {
+		t.Errorf("attempts = %d, want 1 as the body cannot be replayed", got)
+	}
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestBareDo_retryAfter(t *testing.T) {
+	client, mux := setup(t)
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	h := &flakyHandler{
+		t:        t,
+		failures: 1,
+		status:   http.StatusTooManyRequests,
+		header:   http.Header{"Retry-After": {"3600"}},
+	}
+	mux.Handle("/incident.do", h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := client.NewRequest("GET", "incident.do", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v, want Retry-After capped at MaxBackoff", err)
+	}
+	if got := h.attempts(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestBareDo_retryTransportError(t *testing.T) {
+	client, mux := setup(t)
+	client.RetryPolicy = fastRetries()
+	var mu sync.Mutex
+	attempts := 0
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n == 1 {
+			// Close the connection without a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack returned error: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		writeJSON(t, w, records())
+	})
+
+	req, _ := client.NewRequest("GET", "incident.do", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+
+	// An invalid URL is not retried.
+	client.BaseURL, _ = url.Parse("bogus://instance/")
+	req, _ = client.NewRequest("GET", "incident.do", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Errorf("Do with an unsupported scheme returned no error")
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDefaultRetryableError(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://instance/incident.do", Err: err}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"reset", urlErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"refused", urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"eof", urlErr(io.EOF), true},
+		{"timeout", urlErr(timeoutError{}), true},
+		{"tls", urlErr(x509.UnknownAuthorityError{}), false},
+		{"bad url", urlErr(errors.New("unsupported protocol scheme \"bogus\"")), false},
+		{"other", errors.New("other"), false},
+	}
+	for _, tt := range tests {
+		if got := DefaultRetryableError(tt.err); got != tt.want {
+			t.Errorf("DefaultRetryableError(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// A custom RetryableError overrides the default.
+	p := &RetryPolicy{RetryableError: func(error) bool { return true }}
+	req, _ := http.NewRequest("GET", "https://instance/incident.do", nil)
+	if _, ok := p.retryWait(req, nil, urlErr(x509.UnknownAuthorityError{}), 1); !ok {
+		t.Errorf("retryWait with a custom RetryableError did not retry")
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(attempt); d < max/2 || d > max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, d, max/2, max)
+			}
+		}
+	}
+
+	var defaults RetryPolicy
+	if d := defaults.backoff(20); d > defaultMaxBackoff {
+		t.Errorf("backoff with defaults = %v, want at most %v", d, defaultMaxBackoff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		v      string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.v)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.v, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	d, ok := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if !ok || d < 59*time.Minute || d > time.Hour {
+		t.Errorf("parseRetryAfter of a date in an hour = %v, %v", d, ok)
+	}
+}
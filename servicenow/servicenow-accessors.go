@@ -2,2162 +2,2570 @@
 
 package servicenow
 
-// GetActive returns the Active field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetActive() string {
-	if c == nil || c.Active == nil {
+import (
+	"encoding/json"
+)
+
+// GetQuery returns the Query field.
+func (a *AggregateOptions) GetQuery() *Query {
+	if a == nil {
+		return nil
+	}
+	return a.Query
+}
+
+// GetAvg returns the Avg map if it's non-nil, an empty map otherwise.
+func (a *AggregateStats) GetAvg() map[string]float64 {
+	if a == nil || a.Avg == nil {
+		return map[string]float64{}
+	}
+	return a.Avg
+}
+
+// GetMax returns the Max map if it's non-nil, an empty map otherwise.
+func (a *AggregateStats) GetMax() map[string]string {
+	if a == nil || a.Max == nil {
+		return map[string]string{}
+	}
+	return a.Max
+}
+
+// GetMin returns the Min map if it's non-nil, an empty map otherwise.
+func (a *AggregateStats) GetMin() map[string]string {
+	if a == nil || a.Min == nil {
+		return map[string]string{}
+	}
+	return a.Min
+}
+
+// GetSum returns the Sum map if it's non-nil, an empty map otherwise.
+func (a *AggregateStats) GetSum() map[string]float64 {
+	if a == nil || a.Sum == nil {
+		return map[string]float64{}
+	}
+	return a.Sum
+}
+
+// GetCompressed returns the Compressed field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetCompressed() string {
+	if a == nil || a.Compressed == nil {
 		return ""
 	}
-	return *c.Active
+	return *a.Compressed
 }
 
-// GetActivityDue returns the ActivityDue field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetActivityDue() string {
-	if c == nil || c.ActivityDue == nil {
+// GetContentType returns the ContentType field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetContentType() string {
+	if a == nil || a.ContentType == nil {
 		return ""
 	}
-	return *c.ActivityDue
+	return *a.ContentType
 }
 
-// GetAdditionalAssigneeList returns the AdditionalAssigneeList field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetAdditionalAssigneeList() string {
-	if c == nil || c.AdditionalAssigneeList == nil {
+// GetDownloadLink returns the DownloadLink field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetDownloadLink() string {
+	if a == nil || a.DownloadLink == nil {
 		return ""
 	}
-	return *c.AdditionalAssigneeList
+	return *a.DownloadLink
 }
 
-// GetApproval returns the Approval field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetApproval() string {
-	if c == nil || c.Approval == nil {
+// GetFileName returns the FileName field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetFileName() string {
+	if a == nil || a.FileName == nil {
 		return ""
 	}
-	return *c.Approval
+	return *a.FileName
 }
 
-// GetApprovalHistory returns the ApprovalHistory field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetApprovalHistory() string {
-	if c == nil || c.ApprovalHistory == nil {
+// GetHash returns the Hash field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetHash() string {
+	if a == nil || a.Hash == nil {
 		return ""
 	}
-	return *c.ApprovalHistory
+	return *a.Hash
 }
 
-// GetApprovalSet returns the ApprovalSet field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetApprovalSet() string {
-	if c == nil || c.ApprovalSet == nil {
+// GetSizeBytes returns the SizeBytes field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSizeBytes() string {
+	if a == nil || a.SizeBytes == nil {
 		return ""
 	}
-	return *c.ApprovalSet
+	return *a.SizeBytes
 }
 
-// GetAssignedTo returns the AssignedTo field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetAssignedTo() string {
-	if c == nil || c.AssignedTo == nil {
+// GetSizeCompressed returns the SizeCompressed field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSizeCompressed() string {
+	if a == nil || a.SizeCompressed == nil {
 		return ""
 	}
-	return *c.AssignedTo
+	return *a.SizeCompressed
 }
 
-// GetAssignmentGroup returns the AssignmentGroup field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetAssignmentGroup() string {
-	if c == nil || c.AssignmentGroup == nil {
+// GetSysCreatedBy returns the SysCreatedBy field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSysCreatedBy() string {
+	if a == nil || a.SysCreatedBy == nil {
 		return ""
 	}
-	return *c.AssignmentGroup
+	return *a.SysCreatedBy
 }
 
-// GetBackoutPlan returns the BackoutPlan field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetBackoutPlan() string {
-	if c == nil || c.BackoutPlan == nil {
+// GetSysCreatedOn returns the SysCreatedOn field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSysCreatedOn() Timestamp {
+	if a == nil || a.SysCreatedOn == nil {
+		return Timestamp{}
+	}
+	return *a.SysCreatedOn
+}
+
+// GetSysID returns the SysID field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSysID() string {
+	if a == nil || a.SysID == nil {
 		return ""
 	}
-	return *c.BackoutPlan
+	return *a.SysID
 }
 
-// GetBusinessDuration returns the BusinessDuration field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetBusinessDuration() string {
-	if c == nil || c.BusinessDuration == nil {
+// GetSysUpdatedBy returns the SysUpdatedBy field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSysUpdatedBy() string {
+	if a == nil || a.SysUpdatedBy == nil {
 		return ""
 	}
-	return *c.BusinessDuration
+	return *a.SysUpdatedBy
 }
 
-// GetBusinessService returns the BusinessService field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetBusinessService() string {
-	if c == nil || c.BusinessService == nil {
+// GetSysUpdatedOn returns the SysUpdatedOn field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetSysUpdatedOn() Timestamp {
+	if a == nil || a.SysUpdatedOn == nil {
+		return Timestamp{}
+	}
+	return *a.SysUpdatedOn
+}
+
+// GetTableName returns the TableName field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetTableName() string {
+	if a == nil || a.TableName == nil {
+		return ""
+	}
+	return *a.TableName
+}
+
+// GetTableSysID returns the TableSysID field if it's non-nil, zero value otherwise.
+func (a *Attachment) GetTableSysID() string {
+	if a == nil || a.TableSysID == nil {
+		return ""
+	}
+	return *a.TableSysID
+}
+
+// GetActive returns the value of the Active field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetActive() string {
+	if c == nil {
+		return ""
+	}
+	return c.Active.Get()
+}
+
+// GetActivityDue returns the value of the ActivityDue field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetActivityDue() Timestamp {
+	if c == nil {
+		return Timestamp{}
+	}
+	return c.ActivityDue.Get()
+}
+
+// GetAdditionalAssigneeList returns the value of the AdditionalAssigneeList field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetAdditionalAssigneeList() string {
+	if c == nil {
 		return ""
 	}
-	return *c.BusinessService
+	return c.AdditionalAssigneeList.Get()
 }
 
-// GetCabDate returns the CabDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetCabDate() string {
-	if c == nil || c.CabDate == nil {
+// GetApproval returns the value of the Approval field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetApproval() string {
+	if c == nil {
 		return ""
 	}
-	return *c.CabDate
+	return c.Approval.Get()
 }
 
-// GetCabDelegate returns the CabDelegate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetCabDelegate() string {
-	if c == nil || c.CabDelegate == nil {
+// GetApprovalHistory returns the value of the ApprovalHistory field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetApprovalHistory() string {
+	if c == nil {
 		return ""
 	}
-	return *c.CabDelegate
+	return c.ApprovalHistory.Get()
+}
+
+// GetApprovalSet returns the value of the ApprovalSet field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetApprovalSet() Timestamp {
+	if c == nil {
+		return Timestamp{}
+	}
+	return c.ApprovalSet.Get()
+}
+
+// GetAssignedTo returns a copy of the value of the AssignedTo field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetAssignedTo() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.AssignedTo.Value()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// GetAssignmentGroup returns a copy of the value of the AssignmentGroup field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetAssignmentGroup() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.AssignmentGroup.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetCabRecommendation returns the CabRecommendation field if it's non-nil, zero value otherwise.
+// GetBackoutPlan returns the value of the BackoutPlan field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetBackoutPlan() string {
+	if c == nil {
+		return ""
+	}
+	return c.BackoutPlan.Get()
+}
+
+// GetBusinessDuration returns the value of the BusinessDuration field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetBusinessDuration() string {
+	if c == nil {
+		return ""
+	}
+	return c.BusinessDuration.Get()
+}
+
+// GetBusinessService returns a copy of the value of the BusinessService field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetBusinessService() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.BusinessService.Value()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// GetCabDate returns the value of the CabDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetCabDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
+	}
+	return c.CabDate.Get()
+}
+
+// GetCabDelegate returns a copy of the value of the CabDelegate field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetCabDelegate() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.CabDelegate.Value()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// GetCabRecommendation returns the value of the CabRecommendation field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCabRecommendation() string {
-	if c == nil || c.CabRecommendation == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CabRecommendation
+	return c.CabRecommendation.Get()
 }
 
-// GetCabRequired returns the CabRequired field if it's non-nil, zero value otherwise.
+// GetCabRequired returns the value of the CabRequired field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCabRequired() string {
-	if c == nil || c.CabRequired == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CabRequired
+	return c.CabRequired.Get()
 }
 
-// GetCalendarDuration returns the CalendarDuration field if it's non-nil, zero value otherwise.
+// GetCalendarDuration returns the value of the CalendarDuration field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCalendarDuration() string {
-	if c == nil || c.CalendarDuration == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CalendarDuration
+	return c.CalendarDuration.Get()
 }
 
-// GetCategory returns the Category field if it's non-nil, zero value otherwise.
+// GetCategory returns the value of the Category field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCategory() string {
-	if c == nil || c.Category == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Category
+	return c.Category.Get()
 }
 
-// GetChangePlan returns the ChangePlan field if it's non-nil, zero value otherwise.
+// GetChangePlan returns the value of the ChangePlan field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetChangePlan() string {
-	if c == nil || c.ChangePlan == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ChangePlan
+	return c.ChangePlan.Get()
 }
 
-// GetChgModel returns the ChgModel field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetChgModel() string {
-	if c == nil || c.ChgModel == nil {
-		return ""
+// GetChgModel returns a copy of the value of the ChgModel field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetChgModel() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.ChgModel
+	v, ok := c.ChgModel.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetCloseCode returns the CloseCode field if it's non-nil, zero value otherwise.
+// GetCloseCode returns the value of the CloseCode field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCloseCode() string {
-	if c == nil || c.CloseCode == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CloseCode
+	return c.CloseCode.Get()
 }
 
-// GetClosedAt returns the ClosedAt field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetClosedAt() string {
-	if c == nil || c.ClosedAt == nil {
-		return ""
+// GetClosedAt returns the value of the ClosedAt field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetClosedAt() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.ClosedAt
+	return c.ClosedAt.Get()
 }
 
-// GetClosedBy returns the ClosedBy field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetClosedBy() string {
-	if c == nil || c.ClosedBy == nil {
-		return ""
+// GetClosedBy returns a copy of the value of the ClosedBy field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetClosedBy() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.ClosedBy.Value()
+	if !ok {
+		return nil
 	}
-	return *c.ClosedBy
+	return &v
 }
 
-// GetCloseNotes returns the CloseNotes field if it's non-nil, zero value otherwise.
+// GetCloseNotes returns the value of the CloseNotes field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCloseNotes() string {
-	if c == nil || c.CloseNotes == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CloseNotes
+	return c.CloseNotes.Get()
 }
 
-// GetCmdbCi returns the CmdbCi field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetCmdbCi() string {
-	if c == nil || c.CmdbCi == nil {
-		return ""
+// GetCmdbCi returns a copy of the value of the CmdbCi field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetCmdbCi() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.CmdbCi.Value()
+	if !ok {
+		return nil
 	}
-	return *c.CmdbCi
+	return &v
 }
 
-// GetComments returns the Comments field if it's non-nil, zero value otherwise.
+// GetComments returns the value of the Comments field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetComments() string {
-	if c == nil || c.Comments == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Comments
+	return c.Comments.Get()
 }
 
-// GetCommentsAndWorkNotes returns the CommentsAndWorkNotes field if it's non-nil, zero value otherwise.
+// GetCommentsAndWorkNotes returns the value of the CommentsAndWorkNotes field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCommentsAndWorkNotes() string {
-	if c == nil || c.CommentsAndWorkNotes == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CommentsAndWorkNotes
+	return c.CommentsAndWorkNotes.Get()
 }
 
-// GetCompany returns the Company field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetCompany() string {
-	if c == nil || c.Company == nil {
-		return ""
+// GetCompany returns a copy of the value of the Company field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetCompany() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.Company.Value()
+	if !ok {
+		return nil
 	}
-	return *c.Company
+	return &v
 }
 
-// GetConflictLastRun returns the ConflictLastRun field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetConflictLastRun() string {
-	if c == nil || c.ConflictLastRun == nil {
-		return ""
+// GetConflictLastRun returns the value of the ConflictLastRun field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetConflictLastRun() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.ConflictLastRun
+	return c.ConflictLastRun.Get()
 }
 
-// GetConflictStatus returns the ConflictStatus field if it's non-nil, zero value otherwise.
+// GetConflictStatus returns the value of the ConflictStatus field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetConflictStatus() string {
-	if c == nil || c.ConflictStatus == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ConflictStatus
+	return c.ConflictStatus.Get()
 }
 
-// GetContactType returns the ContactType field if it's non-nil, zero value otherwise.
+// GetContactType returns the value of the ContactType field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetContactType() string {
-	if c == nil || c.ContactType == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ContactType
+	return c.ContactType.Get()
 }
 
-// GetCorrelationDisplay returns the CorrelationDisplay field if it's non-nil, zero value otherwise.
+// GetCorrelationDisplay returns the value of the CorrelationDisplay field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCorrelationDisplay() string {
-	if c == nil || c.CorrelationDisplay == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CorrelationDisplay
+	return c.CorrelationDisplay.Get()
 }
 
-// GetCorrelationID returns the CorrelationID field if it's non-nil, zero value otherwise.
+// GetCorrelationID returns the value of the CorrelationID field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetCorrelationID() string {
-	if c == nil || c.CorrelationID == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.CorrelationID
+	return c.CorrelationID.Get()
 }
 
-// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+// GetDescription returns the value of the Description field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetDescription() string {
-	if c == nil || c.Description == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Description
+	return c.Description.Get()
 }
 
-// GetDueDate returns the DueDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetDueDate() string {
-	if c == nil || c.DueDate == nil {
-		return ""
+// GetDueDate returns the value of the DueDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetDueDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.DueDate
+	return c.DueDate.Get()
 }
 
-// GetEndDate returns the EndDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetEndDate() string {
-	if c == nil || c.EndDate == nil {
-		return ""
+// GetEndDate returns the value of the EndDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetEndDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.EndDate
+	return c.EndDate.Get()
 }
 
-// GetEscalation returns the Escalation field if it's non-nil, zero value otherwise.
+// GetEscalation returns the value of the Escalation field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetEscalation() string {
-	if c == nil || c.Escalation == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Escalation
+	return c.Escalation.Get()
 }
 
-// GetExpectedStart returns the ExpectedStart field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetExpectedStart() string {
-	if c == nil || c.ExpectedStart == nil {
-		return ""
+// GetExpectedStart returns the value of the ExpectedStart field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetExpectedStart() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.ExpectedStart
+	return c.ExpectedStart.Get()
 }
 
 // GetExtra returns the Extra map if it's non-nil, an empty map otherwise.
-func (c *ChangeRequest) GetExtra() map[string]string {
+func (c *ChangeRequest) GetExtra() map[string]json.RawMessage {
 	if c == nil || c.Extra == nil {
-		return map[string]string{}
+		return map[string]json.RawMessage{}
 	}
 	return c.Extra
 }
 
-// GetFollowUp returns the FollowUp field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetFollowUp() string {
-	if c == nil || c.FollowUp == nil {
-		return ""
+// GetFollowUp returns the value of the FollowUp field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetFollowUp() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.FollowUp
+	return c.FollowUp.Get()
 }
 
-// GetGroupList returns the GroupList field if it's non-nil, zero value otherwise.
+// GetGroupList returns the value of the GroupList field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetGroupList() string {
-	if c == nil || c.GroupList == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.GroupList
+	return c.GroupList.Get()
 }
 
-// GetImpact returns the Impact field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetImpact() string {
-	if c == nil || c.Impact == nil {
+// GetImpact returns the value of the Impact field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetImpact() Impact {
+	if c == nil {
 		return ""
 	}
-	return *c.Impact
+	return c.Impact.Get()
 }
 
-// GetImplementationPlan returns the ImplementationPlan field if it's non-nil, zero value otherwise.
+// GetImplementationPlan returns the value of the ImplementationPlan field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetImplementationPlan() string {
-	if c == nil || c.ImplementationPlan == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ImplementationPlan
+	return c.ImplementationPlan.Get()
 }
 
-// GetJustification returns the Justification field if it's non-nil, zero value otherwise.
+// GetJustification returns the value of the Justification field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetJustification() string {
-	if c == nil || c.Justification == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Justification
+	return c.Justification.Get()
 }
 
-// GetKnowledge returns the Knowledge field if it's non-nil, zero value otherwise.
+// GetKnowledge returns the value of the Knowledge field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetKnowledge() string {
-	if c == nil || c.Knowledge == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Knowledge
+	return c.Knowledge.Get()
 }
 
-// GetLocation returns the Location field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetLocation() string {
-	if c == nil || c.Location == nil {
-		return ""
+// GetLocation returns a copy of the value of the Location field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetLocation() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.Location.Value()
+	if !ok {
+		return nil
 	}
-	return *c.Location
+	return &v
 }
 
-// GetMadeSLA returns the MadeSLA field if it's non-nil, zero value otherwise.
+// GetMadeSLA returns the value of the MadeSLA field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetMadeSLA() string {
-	if c == nil || c.MadeSLA == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.MadeSLA
+	return c.MadeSLA.Get()
 }
 
-// GetNumber returns the Number field if it's non-nil, zero value otherwise.
+// GetNumber returns the value of the Number field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetNumber() string {
-	if c == nil || c.Number == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Number
+	return c.Number.Get()
 }
 
-// GetOnHold returns the OnHold field if it's non-nil, zero value otherwise.
+// GetOnHold returns the value of the OnHold field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetOnHold() string {
-	if c == nil || c.OnHold == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.OnHold
+	return c.OnHold.Get()
 }
 
-// GetOnHoldReason returns the OnHoldReason field if it's non-nil, zero value otherwise.
+// GetOnHoldReason returns the value of the OnHoldReason field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetOnHoldReason() string {
-	if c == nil || c.OnHoldReason == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.OnHoldReason
+	return c.OnHoldReason.Get()
 }
 
-// GetOnHoldTask returns the OnHoldTask field if it's non-nil, zero value otherwise.
+// GetOnHoldTask returns the value of the OnHoldTask field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetOnHoldTask() string {
-	if c == nil || c.OnHoldTask == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.OnHoldTask
+	return c.OnHoldTask.Get()
 }
 
-// GetOpenedAt returns the OpenedAt field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetOpenedAt() string {
-	if c == nil || c.OpenedAt == nil {
-		return ""
+// GetOpenedAt returns the value of the OpenedAt field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetOpenedAt() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.OpenedAt
+	return c.OpenedAt.Get()
 }
 
-// GetOpenedBy returns the OpenedBy field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetOpenedBy() string {
-	if c == nil || c.OpenedBy == nil {
-		return ""
+// GetOpenedBy returns a copy of the value of the OpenedBy field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetOpenedBy() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.OpenedBy
+	v, ok := c.OpenedBy.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetOrder returns the Order field if it's non-nil, zero value otherwise.
+// GetOrder returns the value of the Order field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetOrder() string {
-	if c == nil || c.Order == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Order
+	return c.Order.Get()
 }
 
-// GetOutsideMaintenanceSchedule returns the OutsideMaintenanceSchedule field if it's non-nil, zero value otherwise.
+// GetOutsideMaintenanceSchedule returns the value of the OutsideMaintenanceSchedule field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetOutsideMaintenanceSchedule() string {
-	if c == nil || c.OutsideMaintenanceSchedule == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.OutsideMaintenanceSchedule
+	return c.OutsideMaintenanceSchedule.Get()
 }
 
-// GetParent returns the Parent field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetParent() string {
-	if c == nil || c.Parent == nil {
-		return ""
+// GetParent returns a copy of the value of the Parent field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetParent() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.Parent
+	v, ok := c.Parent.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetPhase returns the Phase field if it's non-nil, zero value otherwise.
+// GetPhase returns the value of the Phase field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetPhase() string {
-	if c == nil || c.Phase == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Phase
+	return c.Phase.Get()
 }
 
-// GetPhaseState returns the PhaseState field if it's non-nil, zero value otherwise.
+// GetPhaseState returns the value of the PhaseState field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetPhaseState() string {
-	if c == nil || c.PhaseState == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.PhaseState
+	return c.PhaseState.Get()
 }
 
-// GetPriority returns the Priority field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetPriority() string {
-	if c == nil || c.Priority == nil {
+// GetPriority returns the value of the Priority field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetPriority() Priority {
+	if c == nil {
 		return ""
 	}
-	return *c.Priority
+	return c.Priority.Get()
 }
 
-// GetProductionSystem returns the ProductionSystem field if it's non-nil, zero value otherwise.
+// GetProductionSystem returns the value of the ProductionSystem field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetProductionSystem() string {
-	if c == nil || c.ProductionSystem == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ProductionSystem
+	return c.ProductionSystem.Get()
 }
 
-// GetReason returns the Reason field if it's non-nil, zero value otherwise.
+// GetReason returns the value of the Reason field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetReason() string {
-	if c == nil || c.Reason == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Reason
+	return c.Reason.Get()
 }
 
-// GetReassignmentCount returns the ReassignmentCount field if it's non-nil, zero value otherwise.
+// GetReassignmentCount returns the value of the ReassignmentCount field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetReassignmentCount() string {
-	if c == nil || c.ReassignmentCount == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ReassignmentCount
+	return c.ReassignmentCount.Get()
 }
 
-// GetRequestedBy returns the RequestedBy field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetRequestedBy() string {
-	if c == nil || c.RequestedBy == nil {
-		return ""
+// GetRequestedBy returns a copy of the value of the RequestedBy field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetRequestedBy() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.RequestedBy
+	v, ok := c.RequestedBy.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetRequestedByDate returns the RequestedByDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetRequestedByDate() string {
-	if c == nil || c.RequestedByDate == nil {
-		return ""
+// GetRequestedByDate returns the value of the RequestedByDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetRequestedByDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.RequestedByDate
+	return c.RequestedByDate.Get()
 }
 
-// GetReviewComments returns the ReviewComments field if it's non-nil, zero value otherwise.
+// GetReviewComments returns the value of the ReviewComments field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetReviewComments() string {
-	if c == nil || c.ReviewComments == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ReviewComments
+	return c.ReviewComments.Get()
 }
 
-// GetReviewDate returns the ReviewDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetReviewDate() string {
-	if c == nil || c.ReviewDate == nil {
-		return ""
+// GetReviewDate returns the value of the ReviewDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetReviewDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.ReviewDate
+	return c.ReviewDate.Get()
 }
 
-// GetReviewStatus returns the ReviewStatus field if it's non-nil, zero value otherwise.
+// GetReviewStatus returns the value of the ReviewStatus field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetReviewStatus() string {
-	if c == nil || c.ReviewStatus == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ReviewStatus
+	return c.ReviewStatus.Get()
 }
 
-// GetRisk returns the Risk field if it's non-nil, zero value otherwise.
+// GetRisk returns the value of the Risk field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetRisk() string {
-	if c == nil || c.Risk == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Risk
+	return c.Risk.Get()
 }
 
-// GetRiskImpactAnalysis returns the RiskImpactAnalysis field if it's non-nil, zero value otherwise.
+// GetRiskImpactAnalysis returns the value of the RiskImpactAnalysis field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetRiskImpactAnalysis() string {
-	if c == nil || c.RiskImpactAnalysis == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.RiskImpactAnalysis
+	return c.RiskImpactAnalysis.Get()
 }
 
-// GetRiskValue returns the RiskValue field if it's non-nil, zero value otherwise.
+// GetRiskValue returns the value of the RiskValue field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetRiskValue() string {
-	if c == nil || c.RiskValue == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.RiskValue
+	return c.RiskValue.Get()
 }
 
-// GetRouteReason returns the RouteReason field if it's non-nil, zero value otherwise.
+// GetRouteReason returns the value of the RouteReason field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetRouteReason() string {
-	if c == nil || c.RouteReason == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.RouteReason
+	return c.RouteReason.Get()
 }
 
-// GetScope returns the Scope field if it's non-nil, zero value otherwise.
+// GetScope returns the value of the Scope field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetScope() string {
-	if c == nil || c.Scope == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Scope
+	return c.Scope.Get()
 }
 
-// GetServiceOffering returns the ServiceOffering field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetServiceOffering() string {
-	if c == nil || c.ServiceOffering == nil {
-		return ""
+// GetServiceOffering returns a copy of the value of the ServiceOffering field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetServiceOffering() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.ServiceOffering
+	v, ok := c.ServiceOffering.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetShortDescription returns the ShortDescription field if it's non-nil, zero value otherwise.
+// GetShortDescription returns the value of the ShortDescription field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetShortDescription() string {
-	if c == nil || c.ShortDescription == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.ShortDescription
+	return c.ShortDescription.Get()
 }
 
-// GetSkills returns the Skills field if it's non-nil, zero value otherwise.
+// GetSkills returns the value of the Skills field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSkills() string {
-	if c == nil || c.Skills == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Skills
+	return c.Skills.Get()
 }
 
-// GetSLADue returns the SLADue field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetSLADue() string {
-	if c == nil || c.SLADue == nil {
-		return ""
+// GetSLADue returns the value of the SLADue field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetSLADue() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.SLADue
+	return c.SLADue.Get()
 }
 
-// GetSnEsignDocument returns the SnEsignDocument field if it's non-nil, zero value otherwise.
+// GetSnEsignDocument returns the value of the SnEsignDocument field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSnEsignDocument() string {
-	if c == nil || c.SnEsignDocument == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SnEsignDocument
+	return c.SnEsignDocument.Get()
 }
 
-// GetSnEsignEsignatureConfiguration returns the SnEsignEsignatureConfiguration field if it's non-nil, zero value otherwise.
+// GetSnEsignEsignatureConfiguration returns the value of the SnEsignEsignatureConfiguration field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSnEsignEsignatureConfiguration() string {
-	if c == nil || c.SnEsignEsignatureConfiguration == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SnEsignEsignatureConfiguration
+	return c.SnEsignEsignatureConfiguration.Get()
 }
 
-// GetStartDate returns the StartDate field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetStartDate() string {
-	if c == nil || c.StartDate == nil {
-		return ""
+// GetStartDate returns the value of the StartDate field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetStartDate() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.StartDate
+	return c.StartDate.Get()
 }
 
-// GetState returns the State field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetState() string {
-	if c == nil || c.State == nil {
+// GetState returns the value of the State field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetState() ChangeState {
+	if c == nil {
 		return ""
 	}
-	return *c.State
+	return c.State.Get()
 }
 
-// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+// GetStatus returns the value of the Status field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetStatus() string {
-	if c == nil || c.Status == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Status
+	return c.Status.Get()
 }
 
-// GetStdChangeProducerVersion returns the StdChangeProducerVersion field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetStdChangeProducerVersion() string {
-	if c == nil || c.StdChangeProducerVersion == nil {
-		return ""
+// GetStdChangeProducerVersion returns a copy of the value of the StdChangeProducerVersion field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetStdChangeProducerVersion() *Reference {
+	if c == nil {
+		return nil
 	}
-	return *c.StdChangeProducerVersion
+	v, ok := c.StdChangeProducerVersion.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetSysClassName returns the SysClassName field if it's non-nil, zero value otherwise.
+// GetSysClassName returns the value of the SysClassName field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysClassName() string {
-	if c == nil || c.SysClassName == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysClassName
+	return c.SysClassName.Get()
 }
 
-// GetSysCreatedBy returns the SysCreatedBy field if it's non-nil, zero value otherwise.
+// GetSysCreatedBy returns the value of the SysCreatedBy field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysCreatedBy() string {
-	if c == nil || c.SysCreatedBy == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysCreatedBy
+	return c.SysCreatedBy.Get()
 }
 
-// GetSysCreatedOn returns the SysCreatedOn field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetSysCreatedOn() string {
-	if c == nil || c.SysCreatedOn == nil {
-		return ""
+// GetSysCreatedOn returns the value of the SysCreatedOn field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetSysCreatedOn() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.SysCreatedOn
+	return c.SysCreatedOn.Get()
 }
 
-// GetSysDomain returns the SysDomain field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetSysDomain() string {
-	if c == nil || c.SysDomain == nil {
-		return ""
+// GetSysDomain returns a copy of the value of the SysDomain field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetSysDomain() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.SysDomain.Value()
+	if !ok {
+		return nil
 	}
-	return *c.SysDomain
+	return &v
 }
 
-// GetSysDomainPath returns the SysDomainPath field if it's non-nil, zero value otherwise.
+// GetSysDomainPath returns the value of the SysDomainPath field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysDomainPath() string {
-	if c == nil || c.SysDomainPath == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysDomainPath
+	return c.SysDomainPath.Get()
 }
 
-// GetSysID returns the SysID field if it's non-nil, zero value otherwise.
+// GetSysID returns the value of the SysID field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysID() string {
-	if c == nil || c.SysID == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysID
+	return c.SysID.Get()
 }
 
-// GetSysModCount returns the SysModCount field if it's non-nil, zero value otherwise.
+// GetSysModCount returns the value of the SysModCount field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysModCount() string {
-	if c == nil || c.SysModCount == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysModCount
+	return c.SysModCount.Get()
 }
 
-// GetSysTags returns the SysTags field if it's non-nil, zero value otherwise.
+// GetSysTags returns the value of the SysTags field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysTags() string {
-	if c == nil || c.SysTags == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysTags
+	return c.SysTags.Get()
 }
 
-// GetSysUpdatedBy returns the SysUpdatedBy field if it's non-nil, zero value otherwise.
+// GetSysUpdatedBy returns the value of the SysUpdatedBy field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetSysUpdatedBy() string {
-	if c == nil || c.SysUpdatedBy == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.SysUpdatedBy
+	return c.SysUpdatedBy.Get()
 }
 
-// GetSysUpdatedOn returns the SysUpdatedOn field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetSysUpdatedOn() string {
-	if c == nil || c.SysUpdatedOn == nil {
-		return ""
+// GetSysUpdatedOn returns the value of the SysUpdatedOn field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetSysUpdatedOn() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.SysUpdatedOn
+	return c.SysUpdatedOn.Get()
 }
 
-// GetTaskEffectiveNumber returns the TaskEffectiveNumber field if it's non-nil, zero value otherwise.
+// GetTaskEffectiveNumber returns the value of the TaskEffectiveNumber field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetTaskEffectiveNumber() string {
-	if c == nil || c.TaskEffectiveNumber == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.TaskEffectiveNumber
+	return c.TaskEffectiveNumber.Get()
 }
 
-// GetTestPlan returns the TestPlan field if it's non-nil, zero value otherwise.
+// GetTestPlan returns the value of the TestPlan field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetTestPlan() string {
-	if c == nil || c.TestPlan == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.TestPlan
+	return c.TestPlan.Get()
 }
 
-// GetTimeWorked returns the TimeWorked field if it's non-nil, zero value otherwise.
+// GetTimeWorked returns the value of the TimeWorked field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetTimeWorked() string {
-	if c == nil || c.TimeWorked == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.TimeWorked
+	return c.TimeWorked.Get()
 }
 
-// GetType returns the Type field if it's non-nil, zero value otherwise.
+// GetType returns the value of the Type field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetType() string {
-	if c == nil || c.Type == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Type
+	return c.Type.Get()
 }
 
-// GetUnauthorized returns the Unauthorized field if it's non-nil, zero value otherwise.
+// GetUnauthorized returns the value of the Unauthorized field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetUnauthorized() string {
-	if c == nil || c.Unauthorized == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.Unauthorized
+	return c.Unauthorized.Get()
 }
 
-// GetUniversalRequest returns the UniversalRequest field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetUniversalRequest() string {
-	if c == nil || c.UniversalRequest == nil {
-		return ""
+// GetUniversalRequest returns a copy of the value of the UniversalRequest field if it's set and not null, nil otherwise.
+func (c *ChangeRequest) GetUniversalRequest() *Reference {
+	if c == nil {
+		return nil
+	}
+	v, ok := c.UniversalRequest.Value()
+	if !ok {
+		return nil
 	}
-	return *c.UniversalRequest
+	return &v
 }
 
-// GetUponApproval returns the UponApproval field if it's non-nil, zero value otherwise.
+// GetUponApproval returns the value of the UponApproval field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetUponApproval() string {
-	if c == nil || c.UponApproval == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.UponApproval
+	return c.UponApproval.Get()
 }
 
-// GetUponReject returns the UponReject field if it's non-nil, zero value otherwise.
+// GetUponReject returns the value of the UponReject field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetUponReject() string {
-	if c == nil || c.UponReject == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.UponReject
+	return c.UponReject.Get()
 }
 
-// GetUrgency returns the Urgency field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetUrgency() string {
-	if c == nil || c.Urgency == nil {
+// GetUrgency returns the value of the Urgency field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetUrgency() Urgency {
+	if c == nil {
 		return ""
 	}
-	return *c.Urgency
+	return c.Urgency.Get()
 }
 
-// GetUserInput returns the UserInput field if it's non-nil, zero value otherwise.
+// GetUserInput returns the value of the UserInput field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetUserInput() string {
-	if c == nil || c.UserInput == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.UserInput
+	return c.UserInput.Get()
 }
 
-// GetWatchList returns the WatchList field if it's non-nil, zero value otherwise.
+// GetWatchList returns the value of the WatchList field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetWatchList() string {
-	if c == nil || c.WatchList == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.WatchList
+	return c.WatchList.Get()
 }
 
-// GetWorkEnd returns the WorkEnd field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetWorkEnd() string {
-	if c == nil || c.WorkEnd == nil {
-		return ""
+// GetWorkEnd returns the value of the WorkEnd field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetWorkEnd() Timestamp {
+	if c == nil {
+		return Timestamp{}
 	}
-	return *c.WorkEnd
+	return c.WorkEnd.Get()
 }
 
-// GetWorkNotes returns the WorkNotes field if it's non-nil, zero value otherwise.
+// GetWorkNotes returns the value of the WorkNotes field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetWorkNotes() string {
-	if c == nil || c.WorkNotes == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.WorkNotes
+	return c.WorkNotes.Get()
 }
 
-// GetWorkNotesList returns the WorkNotesList field if it's non-nil, zero value otherwise.
+// GetWorkNotesList returns the value of the WorkNotesList field if it's set and not null, zero value otherwise.
 func (c *ChangeRequest) GetWorkNotesList() string {
-	if c == nil || c.WorkNotesList == nil {
+	if c == nil {
 		return ""
 	}
-	return *c.WorkNotesList
+	return c.WorkNotesList.Get()
+}
+
+// GetWorkStart returns the value of the WorkStart field if it's set and not null, zero value otherwise.
+func (c *ChangeRequest) GetWorkStart() Timestamp {
+	if c == nil {
+		return Timestamp{}
+	}
+	return c.WorkStart.Get()
 }
 
-// GetWorkStart returns the WorkStart field if it's non-nil, zero value otherwise.
-func (c *ChangeRequest) GetWorkStart() string {
-	if c == nil || c.WorkStart == nil {
+// GetDisplayValue returns the DisplayValue field if it's non-nil, zero value otherwise.
+func (d *DisplayValuePair) GetDisplayValue() string {
+	if d == nil || d.DisplayValue == nil {
 		return ""
 	}
-	return *c.WorkStart
+	return *d.DisplayValue
 }
 
-// GetActive returns the Active field if it's non-nil, zero value otherwise.
-func (i *Incident) GetActive() string {
-	if i == nil || i.Active == nil {
+// GetValue returns the Value field if it's non-nil, zero value otherwise.
+func (d *DisplayValuePair) GetValue() string {
+	if d == nil || d.Value == nil {
 		return ""
 	}
-	return *i.Active
+	return *d.Value
 }
 
-// GetActivityDue returns the ActivityDue field if it's non-nil, zero value otherwise.
-func (i *Incident) GetActivityDue() string {
-	if i == nil || i.ActivityDue == nil {
+// GetActive returns the value of the Active field if it's set and not null, zero value otherwise.
+func (i *Incident) GetActive() string {
+	if i == nil {
 		return ""
 	}
-	return *i.ActivityDue
+	return i.Active.Get()
+}
+
+// GetActivityDue returns the value of the ActivityDue field if it's set and not null, zero value otherwise.
+func (i *Incident) GetActivityDue() Timestamp {
+	if i == nil {
+		return Timestamp{}
+	}
+	return i.ActivityDue.Get()
 }
 
-// GetAdditionalAssigneeList returns the AdditionalAssigneeList field if it's non-nil, zero value otherwise.
+// GetAdditionalAssigneeList returns the value of the AdditionalAssigneeList field if it's set and not null, zero value otherwise.
 func (i *Incident) GetAdditionalAssigneeList() string {
-	if i == nil || i.AdditionalAssigneeList == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.AdditionalAssigneeList
+	return i.AdditionalAssigneeList.Get()
 }
 
-// GetApproval returns the Approval field if it's non-nil, zero value otherwise.
+// GetApproval returns the value of the Approval field if it's set and not null, zero value otherwise.
 func (i *Incident) GetApproval() string {
-	if i == nil || i.Approval == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Approval
+	return i.Approval.Get()
 }
 
-// GetApprovalHistory returns the ApprovalHistory field if it's non-nil, zero value otherwise.
+// GetApprovalHistory returns the value of the ApprovalHistory field if it's set and not null, zero value otherwise.
 func (i *Incident) GetApprovalHistory() string {
-	if i == nil || i.ApprovalHistory == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ApprovalHistory
+	return i.ApprovalHistory.Get()
 }
 
-// GetApprovalSet returns the ApprovalSet field if it's non-nil, zero value otherwise.
-func (i *Incident) GetApprovalSet() string {
-	if i == nil || i.ApprovalSet == nil {
-		return ""
+// GetApprovalSet returns the value of the ApprovalSet field if it's set and not null, zero value otherwise.
+func (i *Incident) GetApprovalSet() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.ApprovalSet
+	return i.ApprovalSet.Get()
 }
 
-// GetAssignedTo returns the AssignedTo field if it's non-nil, zero value otherwise.
-func (i *Incident) GetAssignedTo() string {
-	if i == nil || i.AssignedTo == nil {
-		return ""
+// GetAssignedTo returns a copy of the value of the AssignedTo field if it's set and not null, nil otherwise.
+func (i *Incident) GetAssignedTo() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.AssignedTo.Value()
+	if !ok {
+		return nil
 	}
-	return *i.AssignedTo
+	return &v
 }
 
-// GetAssignmentGroup returns the AssignmentGroup field if it's non-nil, zero value otherwise.
-func (i *Incident) GetAssignmentGroup() string {
-	if i == nil || i.AssignmentGroup == nil {
-		return ""
+// GetAssignmentGroup returns a copy of the value of the AssignmentGroup field if it's set and not null, nil otherwise.
+func (i *Incident) GetAssignmentGroup() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.AssignmentGroup.Value()
+	if !ok {
+		return nil
 	}
-	return *i.AssignmentGroup
+	return &v
 }
 
-// GetBusinessDuration returns the BusinessDuration field if it's non-nil, zero value otherwise.
+// GetBusinessDuration returns the value of the BusinessDuration field if it's set and not null, zero value otherwise.
 func (i *Incident) GetBusinessDuration() string {
-	if i == nil || i.BusinessDuration == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.BusinessDuration
+	return i.BusinessDuration.Get()
 }
 
-// GetBusinessService returns the BusinessService field if it's non-nil, zero value otherwise.
-func (i *Incident) GetBusinessService() string {
-	if i == nil || i.BusinessService == nil {
-		return ""
+// GetBusinessService returns a copy of the value of the BusinessService field if it's set and not null, nil otherwise.
+func (i *Incident) GetBusinessService() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.BusinessService.Value()
+	if !ok {
+		return nil
 	}
-	return *i.BusinessService
+	return &v
 }
 
-// GetBusinessStc returns the BusinessStc field if it's non-nil, zero value otherwise.
+// GetBusinessStc returns the value of the BusinessStc field if it's set and not null, zero value otherwise.
 func (i *Incident) GetBusinessStc() string {
-	if i == nil || i.BusinessStc == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.BusinessStc
+	return i.BusinessStc.Get()
 }
 
-// GetCalendarDuration returns the CalendarDuration field if it's non-nil, zero value otherwise.
+// GetCalendarDuration returns the value of the CalendarDuration field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCalendarDuration() string {
-	if i == nil || i.CalendarDuration == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CalendarDuration
+	return i.CalendarDuration.Get()
 }
 
-// GetCalendarStc returns the CalendarStc field if it's non-nil, zero value otherwise.
+// GetCalendarStc returns the value of the CalendarStc field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCalendarStc() string {
-	if i == nil || i.CalendarStc == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CalendarStc
+	return i.CalendarStc.Get()
 }
 
-// GetCallerID returns the CallerID field if it's non-nil, zero value otherwise.
-func (i *Incident) GetCallerID() string {
-	if i == nil || i.CallerID == nil {
-		return ""
+// GetCallerID returns a copy of the value of the CallerID field if it's set and not null, nil otherwise.
+func (i *Incident) GetCallerID() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.CallerID.Value()
+	if !ok {
+		return nil
 	}
-	return *i.CallerID
+	return &v
 }
 
-// GetCategory returns the Category field if it's non-nil, zero value otherwise.
+// GetCategory returns the value of the Category field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCategory() string {
-	if i == nil || i.Category == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Category
+	return i.Category.Get()
 }
 
-// GetCausedBy returns the CausedBy field if it's non-nil, zero value otherwise.
-func (i *Incident) GetCausedBy() string {
-	if i == nil || i.CausedBy == nil {
-		return ""
+// GetCausedBy returns a copy of the value of the CausedBy field if it's set and not null, nil otherwise.
+func (i *Incident) GetCausedBy() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.CausedBy.Value()
+	if !ok {
+		return nil
 	}
-	return *i.CausedBy
+	return &v
 }
 
-// GetChildIncidents returns the ChildIncidents field if it's non-nil, zero value otherwise.
+// GetChildIncidents returns the value of the ChildIncidents field if it's set and not null, zero value otherwise.
 func (i *Incident) GetChildIncidents() string {
-	if i == nil || i.ChildIncidents == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ChildIncidents
+	return i.ChildIncidents.Get()
 }
 
-// GetCloseCode returns the CloseCode field if it's non-nil, zero value otherwise.
+// GetCloseCode returns the value of the CloseCode field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCloseCode() string {
-	if i == nil || i.CloseCode == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CloseCode
+	return i.CloseCode.Get()
 }
 
-// GetClosedAt returns the ClosedAt field if it's non-nil, zero value otherwise.
-func (i *Incident) GetClosedAt() string {
-	if i == nil || i.ClosedAt == nil {
-		return ""
+// GetClosedAt returns the value of the ClosedAt field if it's set and not null, zero value otherwise.
+func (i *Incident) GetClosedAt() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.ClosedAt
+	return i.ClosedAt.Get()
 }
 
-// GetClosedBy returns the ClosedBy field if it's non-nil, zero value otherwise.
-func (i *Incident) GetClosedBy() string {
-	if i == nil || i.ClosedBy == nil {
-		return ""
+// GetClosedBy returns a copy of the value of the ClosedBy field if it's set and not null, nil otherwise.
+func (i *Incident) GetClosedBy() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.ClosedBy
+	v, ok := i.ClosedBy.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetCloseNotes returns the CloseNotes field if it's non-nil, zero value otherwise.
+// GetCloseNotes returns the value of the CloseNotes field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCloseNotes() string {
-	if i == nil || i.CloseNotes == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CloseNotes
+	return i.CloseNotes.Get()
 }
 
-// GetCmdbCi returns the CmdbCi field if it's non-nil, zero value otherwise.
-func (i *Incident) GetCmdbCi() string {
-	if i == nil || i.CmdbCi == nil {
-		return ""
+// GetCmdbCi returns a copy of the value of the CmdbCi field if it's set and not null, nil otherwise.
+func (i *Incident) GetCmdbCi() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.CmdbCi
+	v, ok := i.CmdbCi.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetComments returns the Comments field if it's non-nil, zero value otherwise.
+// GetComments returns the value of the Comments field if it's set and not null, zero value otherwise.
 func (i *Incident) GetComments() string {
-	if i == nil || i.Comments == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Comments
+	return i.Comments.Get()
 }
 
-// GetCommentsAndWorkNotes returns the CommentsAndWorkNotes field if it's non-nil, zero value otherwise.
+// GetCommentsAndWorkNotes returns the value of the CommentsAndWorkNotes field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCommentsAndWorkNotes() string {
-	if i == nil || i.CommentsAndWorkNotes == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CommentsAndWorkNotes
+	return i.CommentsAndWorkNotes.Get()
 }
 
-// GetCompany returns the Company field if it's non-nil, zero value otherwise.
-func (i *Incident) GetCompany() string {
-	if i == nil || i.Company == nil {
-		return ""
+// GetCompany returns a copy of the value of the Company field if it's set and not null, nil otherwise.
+func (i *Incident) GetCompany() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.Company
+	v, ok := i.Company.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetContactType returns the ContactType field if it's non-nil, zero value otherwise.
+// GetContactType returns the value of the ContactType field if it's set and not null, zero value otherwise.
 func (i *Incident) GetContactType() string {
-	if i == nil || i.ContactType == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ContactType
+	return i.ContactType.Get()
 }
 
-// GetCorrelationDisplay returns the CorrelationDisplay field if it's non-nil, zero value otherwise.
+// GetCorrelationDisplay returns the value of the CorrelationDisplay field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCorrelationDisplay() string {
-	if i == nil || i.CorrelationDisplay == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CorrelationDisplay
+	return i.CorrelationDisplay.Get()
 }
 
-// GetCorrelationID returns the CorrelationID field if it's non-nil, zero value otherwise.
+// GetCorrelationID returns the value of the CorrelationID field if it's set and not null, zero value otherwise.
 func (i *Incident) GetCorrelationID() string {
-	if i == nil || i.CorrelationID == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.CorrelationID
+	return i.CorrelationID.Get()
 }
 
-// GetDeliveryPlan returns the DeliveryPlan field if it's non-nil, zero value otherwise.
-func (i *Incident) GetDeliveryPlan() string {
-	if i == nil || i.DeliveryPlan == nil {
-		return ""
+// GetDeliveryPlan returns a copy of the value of the DeliveryPlan field if it's set and not null, nil otherwise.
+func (i *Incident) GetDeliveryPlan() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.DeliveryPlan
+	v, ok := i.DeliveryPlan.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetDeliveryTask returns the DeliveryTask field if it's non-nil, zero value otherwise.
-func (i *Incident) GetDeliveryTask() string {
-	if i == nil || i.DeliveryTask == nil {
-		return ""
+// GetDeliveryTask returns a copy of the value of the DeliveryTask field if it's set and not null, nil otherwise.
+func (i *Incident) GetDeliveryTask() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.DeliveryTask
+	v, ok := i.DeliveryTask.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+// GetDescription returns the value of the Description field if it's set and not null, zero value otherwise.
 func (i *Incident) GetDescription() string {
-	if i == nil || i.Description == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Description
+	return i.Description.Get()
 }
 
-// GetDueDate returns the DueDate field if it's non-nil, zero value otherwise.
-func (i *Incident) GetDueDate() string {
-	if i == nil || i.DueDate == nil {
-		return ""
+// GetDueDate returns the value of the DueDate field if it's set and not null, zero value otherwise.
+func (i *Incident) GetDueDate() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.DueDate
+	return i.DueDate.Get()
 }
 
-// GetEscalation returns the Escalation field if it's non-nil, zero value otherwise.
+// GetEscalation returns the value of the Escalation field if it's set and not null, zero value otherwise.
 func (i *Incident) GetEscalation() string {
-	if i == nil || i.Escalation == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Escalation
+	return i.Escalation.Get()
 }
 
-// GetExpectedStart returns the ExpectedStart field if it's non-nil, zero value otherwise.
-func (i *Incident) GetExpectedStart() string {
-	if i == nil || i.ExpectedStart == nil {
-		return ""
+// GetExpectedStart returns the value of the ExpectedStart field if it's set and not null, zero value otherwise.
+func (i *Incident) GetExpectedStart() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.ExpectedStart
+	return i.ExpectedStart.Get()
 }
 
 // GetExtra returns the Extra map if it's non-nil, an empty map otherwise.
-func (i *Incident) GetExtra() map[string]string {
+func (i *Incident) GetExtra() map[string]json.RawMessage {
 	if i == nil || i.Extra == nil {
-		return map[string]string{}
+		return map[string]json.RawMessage{}
 	}
 	return i.Extra
 }
 
-// GetFollowUp returns the FollowUp field if it's non-nil, zero value otherwise.
-func (i *Incident) GetFollowUp() string {
-	if i == nil || i.FollowUp == nil {
-		return ""
+// GetFollowUp returns the value of the FollowUp field if it's set and not null, zero value otherwise.
+func (i *Incident) GetFollowUp() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.FollowUp
+	return i.FollowUp.Get()
 }
 
-// GetGroupList returns the GroupList field if it's non-nil, zero value otherwise.
+// GetGroupList returns the value of the GroupList field if it's set and not null, zero value otherwise.
 func (i *Incident) GetGroupList() string {
-	if i == nil || i.GroupList == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.GroupList
+	return i.GroupList.Get()
 }
 
-// GetImpact returns the Impact field if it's non-nil, zero value otherwise.
-func (i *Incident) GetImpact() string {
-	if i == nil || i.Impact == nil {
+// GetImpact returns the value of the Impact field if it's set and not null, zero value otherwise.
+func (i *Incident) GetImpact() Impact {
+	if i == nil {
 		return ""
 	}
-	return *i.Impact
+	return i.Impact.Get()
 }
 
-// GetIncidentState returns the IncidentState field if it's non-nil, zero value otherwise.
-func (i *Incident) GetIncidentState() string {
-	if i == nil || i.IncidentState == nil {
+// GetIncidentState returns the value of the IncidentState field if it's set and not null, zero value otherwise.
+func (i *Incident) GetIncidentState() IncidentState {
+	if i == nil {
 		return ""
 	}
-	return *i.IncidentState
+	return i.IncidentState.Get()
 }
 
-// GetKnowledge returns the Knowledge field if it's non-nil, zero value otherwise.
+// GetKnowledge returns the value of the Knowledge field if it's set and not null, zero value otherwise.
 func (i *Incident) GetKnowledge() string {
-	if i == nil || i.Knowledge == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Knowledge
+	return i.Knowledge.Get()
 }
 
-// GetLocation returns the Location field if it's non-nil, zero value otherwise.
-func (i *Incident) GetLocation() string {
-	if i == nil || i.Location == nil {
-		return ""
+// GetLocation returns a copy of the value of the Location field if it's set and not null, nil otherwise.
+func (i *Incident) GetLocation() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.Location
+	v, ok := i.Location.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetMadeSLA returns the MadeSLA field if it's non-nil, zero value otherwise.
+// GetMadeSLA returns the value of the MadeSLA field if it's set and not null, zero value otherwise.
 func (i *Incident) GetMadeSLA() string {
-	if i == nil || i.MadeSLA == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.MadeSLA
+	return i.MadeSLA.Get()
 }
 
-// GetNotify returns the Notify field if it's non-nil, zero value otherwise.
+// GetNotify returns the value of the Notify field if it's set and not null, zero value otherwise.
 func (i *Incident) GetNotify() string {
-	if i == nil || i.Notify == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Notify
+	return i.Notify.Get()
 }
 
-// GetNumber returns the Number field if it's non-nil, zero value otherwise.
+// GetNumber returns the value of the Number field if it's set and not null, zero value otherwise.
 func (i *Incident) GetNumber() string {
-	if i == nil || i.Number == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Number
+	return i.Number.Get()
 }
 
-// GetOpenedAt returns the OpenedAt field if it's non-nil, zero value otherwise.
-func (i *Incident) GetOpenedAt() string {
-	if i == nil || i.OpenedAt == nil {
-		return ""
+// GetOpenedAt returns the value of the OpenedAt field if it's set and not null, zero value otherwise.
+func (i *Incident) GetOpenedAt() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.OpenedAt
+	return i.OpenedAt.Get()
 }
 
-// GetOpenedBy returns the OpenedBy field if it's non-nil, zero value otherwise.
-func (i *Incident) GetOpenedBy() string {
-	if i == nil || i.OpenedBy == nil {
-		return ""
+// GetOpenedBy returns a copy of the value of the OpenedBy field if it's set and not null, nil otherwise.
+func (i *Incident) GetOpenedBy() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.OpenedBy.Value()
+	if !ok {
+		return nil
 	}
-	return *i.OpenedBy
+	return &v
 }
 
-// GetOrder returns the Order field if it's non-nil, zero value otherwise.
+// GetOrder returns the value of the Order field if it's set and not null, zero value otherwise.
 func (i *Incident) GetOrder() string {
-	if i == nil || i.Order == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Order
+	return i.Order.Get()
 }
 
-// GetParent returns the Parent field if it's non-nil, zero value otherwise.
-func (i *Incident) GetParent() string {
-	if i == nil || i.Parent == nil {
-		return ""
+// GetParent returns a copy of the value of the Parent field if it's set and not null, nil otherwise.
+func (i *Incident) GetParent() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.Parent.Value()
+	if !ok {
+		return nil
 	}
-	return *i.Parent
+	return &v
 }
 
-// GetParentIncident returns the ParentIncident field if it's non-nil, zero value otherwise.
-func (i *Incident) GetParentIncident() string {
-	if i == nil || i.ParentIncident == nil {
-		return ""
+// GetParentIncident returns a copy of the value of the ParentIncident field if it's set and not null, nil otherwise.
+func (i *Incident) GetParentIncident() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.ParentIncident.Value()
+	if !ok {
+		return nil
 	}
-	return *i.ParentIncident
+	return &v
 }
 
-// GetPriority returns the Priority field if it's non-nil, zero value otherwise.
-func (i *Incident) GetPriority() string {
-	if i == nil || i.Priority == nil {
+// GetPriority returns the value of the Priority field if it's set and not null, zero value otherwise.
+func (i *Incident) GetPriority() Priority {
+	if i == nil {
 		return ""
 	}
-	return *i.Priority
+	return i.Priority.Get()
 }
 
-// GetProblemID returns the ProblemID field if it's non-nil, zero value otherwise.
-func (i *Incident) GetProblemID() string {
-	if i == nil || i.ProblemID == nil {
-		return ""
+// GetProblemID returns a copy of the value of the ProblemID field if it's set and not null, nil otherwise.
+func (i *Incident) GetProblemID() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.ProblemID.Value()
+	if !ok {
+		return nil
 	}
-	return *i.ProblemID
+	return &v
 }
 
-// GetReassignmentCount returns the ReassignmentCount field if it's non-nil, zero value otherwise.
+// GetReassignmentCount returns the value of the ReassignmentCount field if it's set and not null, zero value otherwise.
 func (i *Incident) GetReassignmentCount() string {
-	if i == nil || i.ReassignmentCount == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ReassignmentCount
+	return i.ReassignmentCount.Get()
 }
 
-// GetRejectionGoto returns the RejectionGoto field if it's non-nil, zero value otherwise.
-func (i *Incident) GetRejectionGoto() string {
-	if i == nil || i.RejectionGoto == nil {
-		return ""
+// GetRejectionGoto returns a copy of the value of the RejectionGoto field if it's set and not null, nil otherwise.
+func (i *Incident) GetRejectionGoto() *Reference {
+	if i == nil {
+		return nil
+	}
+	v, ok := i.RejectionGoto.Value()
+	if !ok {
+		return nil
 	}
-	return *i.RejectionGoto
+	return &v
 }
 
-// GetReopenCount returns the ReopenCount field if it's non-nil, zero value otherwise.
+// GetReopenCount returns the value of the ReopenCount field if it's set and not null, zero value otherwise.
 func (i *Incident) GetReopenCount() string {
-	if i == nil || i.ReopenCount == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ReopenCount
+	return i.ReopenCount.Get()
 }
 
-// GetResolvedAt returns the ResolvedAt field if it's non-nil, zero value otherwise.
-func (i *Incident) GetResolvedAt() string {
-	if i == nil || i.ResolvedAt == nil {
-		return ""
+// GetResolvedAt returns the value of the ResolvedAt field if it's set and not null, zero value otherwise.
+func (i *Incident) GetResolvedAt() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.ResolvedAt
+	return i.ResolvedAt.Get()
 }
 
-// GetResolvedBy returns the ResolvedBy field if it's non-nil, zero value otherwise.
-func (i *Incident) GetResolvedBy() string {
-	if i == nil || i.ResolvedBy == nil {
-		return ""
+// GetResolvedBy returns a copy of the value of the ResolvedBy field if it's set and not null, nil otherwise.
+func (i *Incident) GetResolvedBy() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.ResolvedBy
+	v, ok := i.ResolvedBy.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetRfc returns the Rfc field if it's non-nil, zero value otherwise.
-func (i *Incident) GetRfc() string {
-	if i == nil || i.Rfc == nil {
-		return ""
+// GetRfc returns a copy of the value of the Rfc field if it's set and not null, nil otherwise.
+func (i *Incident) GetRfc() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.Rfc
+	v, ok := i.Rfc.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetSeverity returns the Severity field if it's non-nil, zero value otherwise.
+// GetSeverity returns the value of the Severity field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSeverity() string {
-	if i == nil || i.Severity == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Severity
+	return i.Severity.Get()
 }
 
-// GetShortDescription returns the ShortDescription field if it's non-nil, zero value otherwise.
+// GetShortDescription returns the value of the ShortDescription field if it's set and not null, zero value otherwise.
 func (i *Incident) GetShortDescription() string {
-	if i == nil || i.ShortDescription == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.ShortDescription
+	return i.ShortDescription.Get()
 }
 
-// GetSLADue returns the SLADue field if it's non-nil, zero value otherwise.
-func (i *Incident) GetSLADue() string {
-	if i == nil || i.SLADue == nil {
-		return ""
+// GetSLADue returns the value of the SLADue field if it's set and not null, zero value otherwise.
+func (i *Incident) GetSLADue() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.SLADue
+	return i.SLADue.Get()
 }
 
-// GetState returns the State field if it's non-nil, zero value otherwise.
-func (i *Incident) GetState() string {
-	if i == nil || i.State == nil {
+// GetState returns the value of the State field if it's set and not null, zero value otherwise.
+func (i *Incident) GetState() IncidentState {
+	if i == nil {
 		return ""
 	}
-	return *i.State
+	return i.State.Get()
 }
 
-// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+// GetStatus returns the value of the Status field if it's set and not null, zero value otherwise.
 func (i *Incident) GetStatus() string {
-	if i == nil || i.Status == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Status
+	return i.Status.Get()
 }
 
-// GetSubcategory returns the Subcategory field if it's non-nil, zero value otherwise.
+// GetSubcategory returns the value of the Subcategory field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSubcategory() string {
-	if i == nil || i.Subcategory == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.Subcategory
+	return i.Subcategory.Get()
 }
 
-// GetSysClassName returns the SysClassName field if it's non-nil, zero value otherwise.
+// GetSysClassName returns the value of the SysClassName field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysClassName() string {
-	if i == nil || i.SysClassName == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysClassName
+	return i.SysClassName.Get()
 }
 
-// GetSysCreatedBy returns the SysCreatedBy field if it's non-nil, zero value otherwise.
+// GetSysCreatedBy returns the value of the SysCreatedBy field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysCreatedBy() string {
-	if i == nil || i.SysCreatedBy == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysCreatedBy
+	return i.SysCreatedBy.Get()
 }
 
-// GetSysCreatedOn returns the SysCreatedOn field if it's non-nil, zero value otherwise.
-func (i *Incident) GetSysCreatedOn() string {
-	if i == nil || i.SysCreatedOn == nil {
-		return ""
+// GetSysCreatedOn returns the value of the SysCreatedOn field if it's set and not null, zero value otherwise.
+func (i *Incident) GetSysCreatedOn() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.SysCreatedOn
+	return i.SysCreatedOn.Get()
 }
 
-// GetSysDomain returns the SysDomain field if it's non-nil, zero value otherwise.
-func (i *Incident) GetSysDomain() string {
-	if i == nil || i.SysDomain == nil {
-		return ""
+// GetSysDomain returns a copy of the value of the SysDomain field if it's set and not null, nil otherwise.
+func (i *Incident) GetSysDomain() *Reference {
+	if i == nil {
+		return nil
 	}
-	return *i.SysDomain
+	v, ok := i.SysDomain.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetSysDomainPath returns the SysDomainPath field if it's non-nil, zero value otherwise.
+// GetSysDomainPath returns the value of the SysDomainPath field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysDomainPath() string {
-	if i == nil || i.SysDomainPath == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysDomainPath
+	return i.SysDomainPath.Get()
 }
 
-// GetSysID returns the SysID field if it's non-nil, zero value otherwise.
+// GetSysID returns the value of the SysID field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysID() string {
-	if i == nil || i.SysID == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysID
+	return i.SysID.Get()
 }
 
-// GetSysModCount returns the SysModCount field if it's non-nil, zero value otherwise.
+// GetSysModCount returns the value of the SysModCount field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysModCount() string {
-	if i == nil || i.SysModCount == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysModCount
+	return i.SysModCount.Get()
 }
 
-// GetSysTags returns the SysTags field if it's non-nil, zero value otherwise.
+// GetSysTags returns the value of the SysTags field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysTags() string {
-	if i == nil || i.SysTags == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysTags
+	return i.SysTags.Get()
 }
 
-// GetSysUpdatedBy returns the SysUpdatedBy field if it's non-nil, zero value otherwise.
+// GetSysUpdatedBy returns the value of the SysUpdatedBy field if it's set and not null, zero value otherwise.
 func (i *Incident) GetSysUpdatedBy() string {
-	if i == nil || i.SysUpdatedBy == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.SysUpdatedBy
+	return i.SysUpdatedBy.Get()
 }
 
-// GetSysUpdatedOn returns the SysUpdatedOn field if it's non-nil, zero value otherwise.
-func (i *Incident) GetSysUpdatedOn() string {
-	if i == nil || i.SysUpdatedOn == nil {
-		return ""
+// GetSysUpdatedOn returns the value of the SysUpdatedOn field if it's set and not null, zero value otherwise.
+func (i *Incident) GetSysUpdatedOn() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.SysUpdatedOn
+	return i.SysUpdatedOn.Get()
 }
 
-// GetTimeWorked returns the TimeWorked field if it's non-nil, zero value otherwise.
+// GetTimeWorked returns the value of the TimeWorked field if it's set and not null, zero value otherwise.
 func (i *Incident) GetTimeWorked() string {
-	if i == nil || i.TimeWorked == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.TimeWorked
+	return i.TimeWorked.Get()
 }
 
-// GetUponApproval returns the UponApproval field if it's non-nil, zero value otherwise.
+// GetUponApproval returns the value of the UponApproval field if it's set and not null, zero value otherwise.
 func (i *Incident) GetUponApproval() string {
-	if i == nil || i.UponApproval == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.UponApproval
+	return i.UponApproval.Get()
 }
 
-// GetUponReject returns the UponReject field if it's non-nil, zero value otherwise.
+// GetUponReject returns the value of the UponReject field if it's set and not null, zero value otherwise.
 func (i *Incident) GetUponReject() string {
-	if i == nil || i.UponReject == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.UponReject
+	return i.UponReject.Get()
 }
 
-// GetUrgency returns the Urgency field if it's non-nil, zero value otherwise.
-func (i *Incident) GetUrgency() string {
-	if i == nil || i.Urgency == nil {
+// GetUrgency returns the value of the Urgency field if it's set and not null, zero value otherwise.
+func (i *Incident) GetUrgency() Urgency {
+	if i == nil {
 		return ""
 	}
-	return *i.Urgency
+	return i.Urgency.Get()
 }
 
-// GetUserInput returns the UserInput field if it's non-nil, zero value otherwise.
+// GetUserInput returns the value of the UserInput field if it's set and not null, zero value otherwise.
 func (i *Incident) GetUserInput() string {
-	if i == nil || i.UserInput == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.UserInput
+	return i.UserInput.Get()
 }
 
-// GetWatchList returns the WatchList field if it's non-nil, zero value otherwise.
+// GetWatchList returns the value of the WatchList field if it's set and not null, zero value otherwise.
 func (i *Incident) GetWatchList() string {
-	if i == nil || i.WatchList == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.WatchList
+	return i.WatchList.Get()
 }
 
-// GetWfActivity returns the WfActivity field if it's non-nil, zero value otherwise.
+// GetWfActivity returns the value of the WfActivity field if it's set and not null, zero value otherwise.
 func (i *Incident) GetWfActivity() string {
-	if i == nil || i.WfActivity == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.WfActivity
+	return i.WfActivity.Get()
 }
 
-// GetWorkEnd returns the WorkEnd field if it's non-nil, zero value otherwise.
-func (i *Incident) GetWorkEnd() string {
-	if i == nil || i.WorkEnd == nil {
-		return ""
+// GetWorkEnd returns the value of the WorkEnd field if it's set and not null, zero value otherwise.
+func (i *Incident) GetWorkEnd() Timestamp {
+	if i == nil {
+		return Timestamp{}
 	}
-	return *i.WorkEnd
+	return i.WorkEnd.Get()
 }
 
-// GetWorkNotes returns the WorkNotes field if it's non-nil, zero value otherwise.
+// GetWorkNotes returns the value of the WorkNotes field if it's set and not null, zero value otherwise.
 func (i *Incident) GetWorkNotes() string {
-	if i == nil || i.WorkNotes == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.WorkNotes
+	return i.WorkNotes.Get()
 }
 
-// GetWorkNotesList returns the WorkNotesList field if it's non-nil, zero value otherwise.
+// GetWorkNotesList returns the value of the WorkNotesList field if it's set and not null, zero value otherwise.
 func (i *Incident) GetWorkNotesList() string {
-	if i == nil || i.WorkNotesList == nil {
+	if i == nil {
 		return ""
 	}
-	return *i.WorkNotesList
+	return i.WorkNotesList.Get()
 }
 
-// GetWorkStart returns the WorkStart field if it's non-nil, zero value otherwise.
-func (i *Incident) GetWorkStart() string {
-	if i == nil || i.WorkStart == nil {
+// GetWorkStart returns the value of the WorkStart field if it's set and not null, zero value otherwise.
+func (i *Incident) GetWorkStart() Timestamp {
+	if i == nil {
+		return Timestamp{}
+	}
+	return i.WorkStart.Get()
+}
+
+// GetQuery returns the Query field.
+func (l *ListOptions) GetQuery() *Query {
+	if l == nil {
+		return nil
+	}
+	return l.Query
+}
+
+// GetDisplayValue returns the DisplayValue field if it's non-nil, zero value otherwise.
+func (r *Reference) GetDisplayValue() string {
+	if r == nil || r.DisplayValue == nil {
 		return ""
 	}
-	return *i.WorkStart
+	return *r.DisplayValue
 }
 
-// GetActive returns the Active field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetActive() string {
-	if s == nil || s.Active == nil {
+// GetLink returns the Link field if it's non-nil, zero value otherwise.
+func (r *Reference) GetLink() string {
+	if r == nil || r.Link == nil {
+		return ""
+	}
+	return *r.Link
+}
+
+// GetValue returns the Value field if it's non-nil, zero value otherwise.
+func (r *Reference) GetValue() string {
+	if r == nil || r.Value == nil {
 		return ""
 	}
-	return *s.Active
+	return *r.Value
 }
 
-// GetActivityDue returns the ActivityDue field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetActivityDue() string {
-	if s == nil || s.ActivityDue == nil {
+// GetActive returns the value of the Active field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetActive() string {
+	if s == nil {
 		return ""
 	}
-	return *s.ActivityDue
+	return s.Active.Get()
 }
 
-// GetAdditionalAssigneeList returns the AdditionalAssigneeList field if it's non-nil, zero value otherwise.
+// GetActivityDue returns the value of the ActivityDue field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetActivityDue() Timestamp {
+	if s == nil {
+		return Timestamp{}
+	}
+	return s.ActivityDue.Get()
+}
+
+// GetAdditionalAssigneeList returns the value of the AdditionalAssigneeList field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetAdditionalAssigneeList() string {
-	if s == nil || s.AdditionalAssigneeList == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.AdditionalAssigneeList
+	return s.AdditionalAssigneeList.Get()
 }
 
-// GetApproval returns the Approval field if it's non-nil, zero value otherwise.
+// GetApproval returns the value of the Approval field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetApproval() string {
-	if s == nil || s.Approval == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Approval
+	return s.Approval.Get()
 }
 
-// GetApprovalHistory returns the ApprovalHistory field if it's non-nil, zero value otherwise.
+// GetApprovalHistory returns the value of the ApprovalHistory field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetApprovalHistory() string {
-	if s == nil || s.ApprovalHistory == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ApprovalHistory
+	return s.ApprovalHistory.Get()
 }
 
-// GetApprovalSet returns the ApprovalSet field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetApprovalSet() string {
-	if s == nil || s.ApprovalSet == nil {
-		return ""
+// GetApprovalSet returns the value of the ApprovalSet field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetApprovalSet() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.ApprovalSet
+	return s.ApprovalSet.Get()
 }
 
-// GetAssignedTo returns the AssignedTo field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetAssignedTo() string {
-	if s == nil || s.AssignedTo == nil {
-		return ""
+// GetAssignedTo returns a copy of the value of the AssignedTo field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetAssignedTo() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.AssignedTo.Value()
+	if !ok {
+		return nil
 	}
-	return *s.AssignedTo
+	return &v
 }
 
-// GetAssignmentGroup returns the AssignmentGroup field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetAssignmentGroup() string {
-	if s == nil || s.AssignmentGroup == nil {
-		return ""
+// GetAssignmentGroup returns a copy of the value of the AssignmentGroup field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetAssignmentGroup() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.AssignmentGroup.Value()
+	if !ok {
+		return nil
 	}
-	return *s.AssignmentGroup
+	return &v
 }
 
-// GetBusinessDuration returns the BusinessDuration field if it's non-nil, zero value otherwise.
+// GetBusinessDuration returns the value of the BusinessDuration field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetBusinessDuration() string {
-	if s == nil || s.BusinessDuration == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.BusinessDuration
+	return s.BusinessDuration.Get()
 }
 
-// GetBusinessJustification returns the BusinessJustification field if it's non-nil, zero value otherwise.
+// GetBusinessJustification returns the value of the BusinessJustification field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetBusinessJustification() string {
-	if s == nil || s.BusinessJustification == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.BusinessJustification
+	return s.BusinessJustification.Get()
 }
 
-// GetBusinessService returns the BusinessService field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetBusinessService() string {
-	if s == nil || s.BusinessService == nil {
-		return ""
+// GetBusinessService returns a copy of the value of the BusinessService field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetBusinessService() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.BusinessService.Value()
+	if !ok {
+		return nil
 	}
-	return *s.BusinessService
+	return &v
 }
 
-// GetCalendarDuration returns the CalendarDuration field if it's non-nil, zero value otherwise.
+// GetCalendarDuration returns the value of the CalendarDuration field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCalendarDuration() string {
-	if s == nil || s.CalendarDuration == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.CalendarDuration
+	return s.CalendarDuration.Get()
 }
 
-// GetCatalog returns the Catalog field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetCatalog() string {
-	if s == nil || s.Catalog == nil {
-		return ""
+// GetCatalog returns a copy of the value of the Catalog field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetCatalog() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.Catalog.Value()
+	if !ok {
+		return nil
 	}
-	return *s.Catalog
+	return &v
 }
 
-// GetCategory returns the Category field if it's non-nil, zero value otherwise.
+// GetCategory returns the value of the Category field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCategory() string {
-	if s == nil || s.Category == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Category
+	return s.Category.Get()
 }
 
-// GetChangeRequests returns the ChangeRequests field if it's non-nil, zero value otherwise.
+// GetChangeRequests returns the value of the ChangeRequests field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetChangeRequests() string {
-	if s == nil || s.ChangeRequests == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ChangeRequests
+	return s.ChangeRequests.Get()
 }
 
-// GetClosedAt returns the ClosedAt field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetClosedAt() string {
-	if s == nil || s.ClosedAt == nil {
-		return ""
+// GetClosedAt returns the value of the ClosedAt field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetClosedAt() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.ClosedAt
+	return s.ClosedAt.Get()
 }
 
-// GetClosedBy returns the ClosedBy field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetClosedBy() string {
-	if s == nil || s.ClosedBy == nil {
-		return ""
+// GetClosedBy returns a copy of the value of the ClosedBy field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetClosedBy() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.ClosedBy
+	v, ok := s.ClosedBy.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetCloseNotes returns the CloseNotes field if it's non-nil, zero value otherwise.
+// GetCloseNotes returns the value of the CloseNotes field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCloseNotes() string {
-	if s == nil || s.CloseNotes == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.CloseNotes
+	return s.CloseNotes.Get()
 }
 
-// GetCmdbCi returns the CmdbCi field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetCmdbCi() string {
-	if s == nil || s.CmdbCi == nil {
-		return ""
+// GetCmdbCi returns a copy of the value of the CmdbCi field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetCmdbCi() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.CmdbCi
+	v, ok := s.CmdbCi.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetComments returns the Comments field if it's non-nil, zero value otherwise.
+// GetComments returns the value of the Comments field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetComments() string {
-	if s == nil || s.Comments == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Comments
+	return s.Comments.Get()
 }
 
-// GetCommentsAndWorkNotes returns the CommentsAndWorkNotes field if it's non-nil, zero value otherwise.
+// GetCommentsAndWorkNotes returns the value of the CommentsAndWorkNotes field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCommentsAndWorkNotes() string {
-	if s == nil || s.CommentsAndWorkNotes == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.CommentsAndWorkNotes
+	return s.CommentsAndWorkNotes.Get()
 }
 
-// GetCompany returns the Company field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetCompany() string {
-	if s == nil || s.Company == nil {
-		return ""
+// GetCompany returns a copy of the value of the Company field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetCompany() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.Company
+	v, ok := s.Company.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetContactType returns the ContactType field if it's non-nil, zero value otherwise.
+// GetContactType returns the value of the ContactType field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetContactType() string {
-	if s == nil || s.ContactType == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ContactType
+	return s.ContactType.Get()
 }
 
-// GetCorrelationDisplay returns the CorrelationDisplay field if it's non-nil, zero value otherwise.
+// GetCorrelationDisplay returns the value of the CorrelationDisplay field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCorrelationDisplay() string {
-	if s == nil || s.CorrelationDisplay == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.CorrelationDisplay
+	return s.CorrelationDisplay.Get()
 }
 
-// GetCorrelationID returns the CorrelationID field if it's non-nil, zero value otherwise.
+// GetCorrelationID returns the value of the CorrelationID field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetCorrelationID() string {
-	if s == nil || s.CorrelationID == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.CorrelationID
+	return s.CorrelationID.Get()
 }
 
-// GetCreatedFromChange returns the CreatedFromChange field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetCreatedFromChange() string {
-	if s == nil || s.CreatedFromChange == nil {
-		return ""
+// GetCreatedFromChange returns a copy of the value of the CreatedFromChange field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetCreatedFromChange() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.CreatedFromChange
+	v, ok := s.CreatedFromChange.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+// GetDescription returns the value of the Description field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetDescription() string {
-	if s == nil || s.Description == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Description
+	return s.Description.Get()
 }
 
-// GetDueDate returns the DueDate field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetDueDate() string {
-	if s == nil || s.DueDate == nil {
-		return ""
+// GetDueDate returns the value of the DueDate field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetDueDate() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.DueDate
+	return s.DueDate.Get()
 }
 
-// GetEscalation returns the Escalation field if it's non-nil, zero value otherwise.
+// GetEscalation returns the value of the Escalation field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetEscalation() string {
-	if s == nil || s.Escalation == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Escalation
+	return s.Escalation.Get()
 }
 
-// GetExpectedStart returns the ExpectedStart field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetExpectedStart() string {
-	if s == nil || s.ExpectedStart == nil {
-		return ""
+// GetExpectedStart returns the value of the ExpectedStart field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetExpectedStart() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.ExpectedStart
+	return s.ExpectedStart.Get()
 }
 
 // GetExtra returns the Extra map if it's non-nil, an empty map otherwise.
-func (s *StandardChangeTemplate) GetExtra() map[string]string {
+func (s *StandardChangeTemplate) GetExtra() map[string]json.RawMessage {
 	if s == nil || s.Extra == nil {
-		return map[string]string{}
+		return map[string]json.RawMessage{}
 	}
 	return s.Extra
 }
 
-// GetFollowUp returns the FollowUp field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetFollowUp() string {
-	if s == nil || s.FollowUp == nil {
-		return ""
+// GetFollowUp returns the value of the FollowUp field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetFollowUp() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.FollowUp
+	return s.FollowUp.Get()
 }
 
-// GetGroupList returns the GroupList field if it's non-nil, zero value otherwise.
+// GetGroupList returns the value of the GroupList field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetGroupList() string {
-	if s == nil || s.GroupList == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.GroupList
+	return s.GroupList.Get()
 }
 
-// GetImpact returns the Impact field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetImpact() string {
-	if s == nil || s.Impact == nil {
+// GetImpact returns the value of the Impact field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetImpact() Impact {
+	if s == nil {
 		return ""
 	}
-	return *s.Impact
+	return s.Impact.Get()
 }
 
-// GetKnowledge returns the Knowledge field if it's non-nil, zero value otherwise.
+// GetKnowledge returns the value of the Knowledge field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetKnowledge() string {
-	if s == nil || s.Knowledge == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Knowledge
+	return s.Knowledge.Get()
 }
 
-// GetLocation returns the Location field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetLocation() string {
-	if s == nil || s.Location == nil {
-		return ""
+// GetLocation returns a copy of the value of the Location field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetLocation() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.Location
+	v, ok := s.Location.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetMadeSLA returns the MadeSLA field if it's non-nil, zero value otherwise.
+// GetMadeSLA returns the value of the MadeSLA field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetMadeSLA() string {
-	if s == nil || s.MadeSLA == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.MadeSLA
+	return s.MadeSLA.Get()
 }
 
-// GetNumber returns the Number field if it's non-nil, zero value otherwise.
+// GetNumber returns the value of the Number field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetNumber() string {
-	if s == nil || s.Number == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Number
+	return s.Number.Get()
 }
 
-// GetOpenedAt returns the OpenedAt field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetOpenedAt() string {
-	if s == nil || s.OpenedAt == nil {
-		return ""
+// GetOpenedAt returns the value of the OpenedAt field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetOpenedAt() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.OpenedAt
+	return s.OpenedAt.Get()
 }
 
-// GetOpenedBy returns the OpenedBy field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetOpenedBy() string {
-	if s == nil || s.OpenedBy == nil {
-		return ""
+// GetOpenedBy returns a copy of the value of the OpenedBy field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetOpenedBy() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.OpenedBy.Value()
+	if !ok {
+		return nil
 	}
-	return *s.OpenedBy
+	return &v
 }
 
-// GetOrder returns the Order field if it's non-nil, zero value otherwise.
+// GetOrder returns the value of the Order field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetOrder() string {
-	if s == nil || s.Order == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Order
+	return s.Order.Get()
 }
 
-// GetParent returns the Parent field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetParent() string {
-	if s == nil || s.Parent == nil {
-		return ""
+// GetParent returns a copy of the value of the Parent field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetParent() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.Parent.Value()
+	if !ok {
+		return nil
 	}
-	return *s.Parent
+	return &v
 }
 
-// GetPriority returns the Priority field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetPriority() string {
-	if s == nil || s.Priority == nil {
+// GetPriority returns the value of the Priority field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetPriority() Priority {
+	if s == nil {
 		return ""
 	}
-	return *s.Priority
+	return s.Priority.Get()
 }
 
-// GetProposalType returns the ProposalType field if it's non-nil, zero value otherwise.
+// GetProposalType returns the value of the ProposalType field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetProposalType() string {
-	if s == nil || s.ProposalType == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ProposalType
+	return s.ProposalType.Get()
 }
 
-// GetReassignmentCount returns the ReassignmentCount field if it's non-nil, zero value otherwise.
+// GetReassignmentCount returns the value of the ReassignmentCount field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetReassignmentCount() string {
-	if s == nil || s.ReassignmentCount == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ReassignmentCount
+	return s.ReassignmentCount.Get()
 }
 
-// GetRouteReason returns the RouteReason field if it's non-nil, zero value otherwise.
+// GetRouteReason returns the value of the RouteReason field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetRouteReason() string {
-	if s == nil || s.RouteReason == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.RouteReason
+	return s.RouteReason.Get()
 }
 
-// GetServiceOffering returns the ServiceOffering field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetServiceOffering() string {
-	if s == nil || s.ServiceOffering == nil {
-		return ""
+// GetServiceOffering returns a copy of the value of the ServiceOffering field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetServiceOffering() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.ServiceOffering.Value()
+	if !ok {
+		return nil
 	}
-	return *s.ServiceOffering
+	return &v
 }
 
-// GetShortDescription returns the ShortDescription field if it's non-nil, zero value otherwise.
+// GetShortDescription returns the value of the ShortDescription field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetShortDescription() string {
-	if s == nil || s.ShortDescription == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.ShortDescription
+	return s.ShortDescription.Get()
 }
 
-// GetSkills returns the Skills field if it's non-nil, zero value otherwise.
+// GetSkills returns the value of the Skills field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSkills() string {
-	if s == nil || s.Skills == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Skills
+	return s.Skills.Get()
 }
 
-// GetSLADue returns the SLADue field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetSLADue() string {
-	if s == nil || s.SLADue == nil {
-		return ""
+// GetSLADue returns the value of the SLADue field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetSLADue() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.SLADue
+	return s.SLADue.Get()
 }
 
-// GetSnEsignDocument returns the SnEsignDocument field if it's non-nil, zero value otherwise.
+// GetSnEsignDocument returns the value of the SnEsignDocument field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSnEsignDocument() string {
-	if s == nil || s.SnEsignDocument == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SnEsignDocument
+	return s.SnEsignDocument.Get()
 }
 
-// GetSnEsignEsignatureConfiguration returns the SnEsignEsignatureConfiguration field if it's non-nil, zero value otherwise.
+// GetSnEsignEsignatureConfiguration returns the value of the SnEsignEsignatureConfiguration field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSnEsignEsignatureConfiguration() string {
-	if s == nil || s.SnEsignEsignatureConfiguration == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SnEsignEsignatureConfiguration
+	return s.SnEsignEsignatureConfiguration.Get()
 }
 
-// GetState returns the State field if it's non-nil, zero value otherwise.
+// GetState returns the value of the State field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetState() string {
-	if s == nil || s.State == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.State
+	return s.State.Get()
 }
 
-// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+// GetStatus returns the value of the Status field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetStatus() string {
-	if s == nil || s.Status == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.Status
+	return s.Status.Get()
 }
 
-// GetStdChangeProducer returns the StdChangeProducer field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetStdChangeProducer() string {
-	if s == nil || s.StdChangeProducer == nil {
-		return ""
+// GetStdChangeProducer returns a copy of the value of the StdChangeProducer field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetStdChangeProducer() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.StdChangeProducer
+	v, ok := s.StdChangeProducer.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetStdChangeProducerVersion returns the StdChangeProducerVersion field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetStdChangeProducerVersion() string {
-	if s == nil || s.StdChangeProducerVersion == nil {
-		return ""
+// GetStdChangeProducerVersion returns a copy of the value of the StdChangeProducerVersion field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetStdChangeProducerVersion() *Reference {
+	if s == nil {
+		return nil
 	}
-	return *s.StdChangeProducerVersion
+	v, ok := s.StdChangeProducerVersion.Value()
+	if !ok {
+		return nil
+	}
+	return &v
 }
 
-// GetSysClassName returns the SysClassName field if it's non-nil, zero value otherwise.
+// GetSysClassName returns the value of the SysClassName field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysClassName() string {
-	if s == nil || s.SysClassName == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysClassName
+	return s.SysClassName.Get()
 }
 
-// GetSysCreatedBy returns the SysCreatedBy field if it's non-nil, zero value otherwise.
+// GetSysCreatedBy returns the value of the SysCreatedBy field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysCreatedBy() string {
-	if s == nil || s.SysCreatedBy == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysCreatedBy
+	return s.SysCreatedBy.Get()
 }
 
-// GetSysCreatedOn returns the SysCreatedOn field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetSysCreatedOn() string {
-	if s == nil || s.SysCreatedOn == nil {
-		return ""
+// GetSysCreatedOn returns the value of the SysCreatedOn field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetSysCreatedOn() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.SysCreatedOn
+	return s.SysCreatedOn.Get()
 }
 
-// GetSysDomain returns the SysDomain field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetSysDomain() string {
-	if s == nil || s.SysDomain == nil {
-		return ""
+// GetSysDomain returns a copy of the value of the SysDomain field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetSysDomain() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.SysDomain.Value()
+	if !ok {
+		return nil
 	}
-	return *s.SysDomain
+	return &v
 }
 
-// GetSysDomainPath returns the SysDomainPath field if it's non-nil, zero value otherwise.
+// GetSysDomainPath returns the value of the SysDomainPath field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysDomainPath() string {
-	if s == nil || s.SysDomainPath == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysDomainPath
+	return s.SysDomainPath.Get()
 }
 
-// GetSysID returns the SysID field if it's non-nil, zero value otherwise.
+// GetSysID returns the value of the SysID field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysID() string {
-	if s == nil || s.SysID == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysID
+	return s.SysID.Get()
 }
 
-// GetSysModCount returns the SysModCount field if it's non-nil, zero value otherwise.
+// GetSysModCount returns the value of the SysModCount field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysModCount() string {
-	if s == nil || s.SysModCount == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysModCount
+	return s.SysModCount.Get()
 }
 
-// GetSysTags returns the SysTags field if it's non-nil, zero value otherwise.
+// GetSysTags returns the value of the SysTags field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysTags() string {
-	if s == nil || s.SysTags == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysTags
+	return s.SysTags.Get()
 }
 
-// GetSysUpdatedBy returns the SysUpdatedBy field if it's non-nil, zero value otherwise.
+// GetSysUpdatedBy returns the value of the SysUpdatedBy field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetSysUpdatedBy() string {
-	if s == nil || s.SysUpdatedBy == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.SysUpdatedBy
+	return s.SysUpdatedBy.Get()
 }
 
-// GetSysUpdatedOn returns the SysUpdatedOn field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetSysUpdatedOn() string {
-	if s == nil || s.SysUpdatedOn == nil {
-		return ""
+// GetSysUpdatedOn returns the value of the SysUpdatedOn field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetSysUpdatedOn() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.SysUpdatedOn
+	return s.SysUpdatedOn.Get()
 }
 
-// GetTaskEffectiveNumber returns the TaskEffectiveNumber field if it's non-nil, zero value otherwise.
+// GetTaskEffectiveNumber returns the value of the TaskEffectiveNumber field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetTaskEffectiveNumber() string {
-	if s == nil || s.TaskEffectiveNumber == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.TaskEffectiveNumber
+	return s.TaskEffectiveNumber.Get()
 }
 
-// GetTemplateName returns the TemplateName field if it's non-nil, zero value otherwise.
+// GetTemplateName returns the value of the TemplateName field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetTemplateName() string {
-	if s == nil || s.TemplateName == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.TemplateName
+	return s.TemplateName.Get()
 }
 
-// GetTemplateValue returns the TemplateValue field if it's non-nil, zero value otherwise.
+// GetTemplateValue returns the value of the TemplateValue field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetTemplateValue() string {
-	if s == nil || s.TemplateValue == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.TemplateValue
+	return s.TemplateValue.Get()
 }
 
-// GetTimeWorked returns the TimeWorked field if it's non-nil, zero value otherwise.
+// GetTimeWorked returns the value of the TimeWorked field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetTimeWorked() string {
-	if s == nil || s.TimeWorked == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.TimeWorked
+	return s.TimeWorked.Get()
 }
 
-// GetUniversalRequest returns the UniversalRequest field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetUniversalRequest() string {
-	if s == nil || s.UniversalRequest == nil {
-		return ""
+// GetUniversalRequest returns a copy of the value of the UniversalRequest field if it's set and not null, nil otherwise.
+func (s *StandardChangeTemplate) GetUniversalRequest() *Reference {
+	if s == nil {
+		return nil
+	}
+	v, ok := s.UniversalRequest.Value()
+	if !ok {
+		return nil
 	}
-	return *s.UniversalRequest
+	return &v
 }
 
-// GetUponApproval returns the UponApproval field if it's non-nil, zero value otherwise.
+// GetUponApproval returns the value of the UponApproval field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetUponApproval() string {
-	if s == nil || s.UponApproval == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.UponApproval
+	return s.UponApproval.Get()
 }
 
-// GetUponReject returns the UponReject field if it's non-nil, zero value otherwise.
+// GetUponReject returns the value of the UponReject field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetUponReject() string {
-	if s == nil || s.UponReject == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.UponReject
+	return s.UponReject.Get()
 }
 
-// GetUrgency returns the Urgency field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetUrgency() string {
-	if s == nil || s.Urgency == nil {
+// GetUrgency returns the value of the Urgency field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetUrgency() Urgency {
+	if s == nil {
 		return ""
 	}
-	return *s.Urgency
+	return s.Urgency.Get()
 }
 
-// GetUserInput returns the UserInput field if it's non-nil, zero value otherwise.
+// GetUserInput returns the value of the UserInput field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetUserInput() string {
-	if s == nil || s.UserInput == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.UserInput
+	return s.UserInput.Get()
 }
 
-// GetWatchList returns the WatchList field if it's non-nil, zero value otherwise.
+// GetWatchList returns the value of the WatchList field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetWatchList() string {
-	if s == nil || s.WatchList == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.WatchList
+	return s.WatchList.Get()
 }
 
-// GetWorkEnd returns the WorkEnd field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetWorkEnd() string {
-	if s == nil || s.WorkEnd == nil {
-		return ""
+// GetWorkEnd returns the value of the WorkEnd field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetWorkEnd() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.WorkEnd
+	return s.WorkEnd.Get()
 }
 
-// GetWorkNotes returns the WorkNotes field if it's non-nil, zero value otherwise.
+// GetWorkNotes returns the value of the WorkNotes field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetWorkNotes() string {
-	if s == nil || s.WorkNotes == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.WorkNotes
+	return s.WorkNotes.Get()
 }
 
-// GetWorkNotesList returns the WorkNotesList field if it's non-nil, zero value otherwise.
+// GetWorkNotesList returns the value of the WorkNotesList field if it's set and not null, zero value otherwise.
 func (s *StandardChangeTemplate) GetWorkNotesList() string {
-	if s == nil || s.WorkNotesList == nil {
+	if s == nil {
 		return ""
 	}
-	return *s.WorkNotesList
+	return s.WorkNotesList.Get()
 }
 
-// GetWorkStart returns the WorkStart field if it's non-nil, zero value otherwise.
-func (s *StandardChangeTemplate) GetWorkStart() string {
-	if s == nil || s.WorkStart == nil {
-		return ""
+// GetWorkStart returns the value of the WorkStart field if it's set and not null, zero value otherwise.
+func (s *StandardChangeTemplate) GetWorkStart() Timestamp {
+	if s == nil {
+		return Timestamp{}
 	}
-	return *s.WorkStart
+	return s.WorkStart.Get()
 }
@@ -0,0 +1,282 @@
+// Code generated by gen-fields; DO NOT EDIT.
+
+package servicenow
+
+// Field names of ChangeRequest, for use in ListOptions.Fields, GetOptions.Fields
+// and queries.
+const (
+	ChangeRequestFieldActive                         = "active"
+	ChangeRequestFieldActivityDue                    = "activity_due"
+	ChangeRequestFieldAdditionalAssigneeList         = "additional_assignee_list"
+	ChangeRequestFieldApproval                       = "approval"
+	ChangeRequestFieldApprovalHistory                = "approval_history"
+	ChangeRequestFieldApprovalSet                    = "approval_set"
+	ChangeRequestFieldAssignedTo                     = "assigned_to"
+	ChangeRequestFieldAssignmentGroup                = "assignment_group"
+	ChangeRequestFieldBackoutPlan                    = "backout_plan"
+	ChangeRequestFieldBusinessDuration               = "business_duration"
+	ChangeRequestFieldBusinessService                = "business_service"
+	ChangeRequestFieldCabDate                        = "cab_date"
+	ChangeRequestFieldCabDelegate                    = "cab_delegate"
+	ChangeRequestFieldCabRecommendation              = "cab_recommendation"
+	ChangeRequestFieldCabRequired                    = "cab_required"
+	ChangeRequestFieldCalendarDuration               = "calendar_duration"
+	ChangeRequestFieldCategory                       = "category"
+	ChangeRequestFieldChangePlan                     = "change_plan"
+	ChangeRequestFieldChgModel                       = "chg_model"
+	ChangeRequestFieldCloseCode                      = "close_code"
+	ChangeRequestFieldCloseNotes                     = "close_notes"
+	ChangeRequestFieldClosedAt                       = "closed_at"
+	ChangeRequestFieldClosedBy                       = "closed_by"
+	ChangeRequestFieldCmdbCi                         = "cmdb_ci"
+	ChangeRequestFieldComments                       = "comments"
+	ChangeRequestFieldCommentsAndWorkNotes           = "comments_and_work_notes"
+	ChangeRequestFieldCompany                        = "company"
+	ChangeRequestFieldConflictLastRun                = "conflict_last_run"
+	ChangeRequestFieldConflictStatus                 = "conflict_status"
+	ChangeRequestFieldContactType                    = "contact_type"
+	ChangeRequestFieldCorrelationDisplay             = "correlation_display"
+	ChangeRequestFieldCorrelationID                  = "correlation_id"
+	ChangeRequestFieldDescription                    = "description"
+	ChangeRequestFieldDueDate                        = "due_date"
+	ChangeRequestFieldEndDate                        = "end_date"
+	ChangeRequestFieldEscalation                     = "escalation"
+	ChangeRequestFieldExpectedStart                  = "expected_start"
+	ChangeRequestFieldFollowUp                       = "follow_up"
+	ChangeRequestFieldGroupList                      = "group_list"
+	ChangeRequestFieldImpact                         = "impact"
+	ChangeRequestFieldImplementationPlan             = "implementation_plan"
+	ChangeRequestFieldJustification                  = "justification"
+	ChangeRequestFieldKnowledge                      = "knowledge"
+	ChangeRequestFieldLocation                       = "location"
+	ChangeRequestFieldMadeSLA                        = "made_sla"
+	ChangeRequestFieldNumber                         = "number"
+	ChangeRequestFieldOnHold                         = "on_hold"
+	ChangeRequestFieldOnHoldReason                   = "on_hold_reason"
+	ChangeRequestFieldOnHoldTask                     = "on_hold_task"
+	ChangeRequestFieldOpenedAt                       = "opened_at"
+	ChangeRequestFieldOpenedBy                       = "opened_by"
+	ChangeRequestFieldOrder                          = "order"
+	ChangeRequestFieldOutsideMaintenanceSchedule     = "outside_maintenance_schedule"
+	ChangeRequestFieldParent                         = "parent"
+	ChangeRequestFieldPhase                          = "phase"
+	ChangeRequestFieldPhaseState                     = "phase_state"
+	ChangeRequestFieldPriority                       = "priority"
+	ChangeRequestFieldProductionSystem               = "production_system"
+	ChangeRequestFieldReason                         = "reason"
+	ChangeRequestFieldReassignmentCount              = "reassignment_count"
+	ChangeRequestFieldRequestedBy                    = "requested_by"
+	ChangeRequestFieldRequestedByDate                = "requested_by_date"
+	ChangeRequestFieldReviewComments                 = "review_comments"
+	ChangeRequestFieldReviewDate                     = "review_date"
+	ChangeRequestFieldReviewStatus                   = "review_status"
+	ChangeRequestFieldRisk                           = "risk"
+	ChangeRequestFieldRiskImpactAnalysis             = "risk_impact_analysis"
+	ChangeRequestFieldRiskValue                      = "risk_value"
+	ChangeRequestFieldRouteReason                    = "route_reason"
+	ChangeRequestFieldScope                          = "scope"
+	ChangeRequestFieldServiceOffering                = "service_offering"
+	ChangeRequestFieldShortDescription               = "short_description"
+	ChangeRequestFieldSkills                         = "skills"
+	ChangeRequestFieldSLADue                         = "sla_due"
+	ChangeRequestFieldSnEsignDocument                = "sn_esign_document"
+	ChangeRequestFieldSnEsignEsignatureConfiguration = "sn_esign_esignature_configuration"
+	ChangeRequestFieldStartDate                      = "start_date"
+	ChangeRequestFieldState                          = "state"
+	ChangeRequestFieldStdChangeProducerVersion       = "std_change_producer_version"
+	ChangeRequestFieldSysClassName                   = "sys_class_name"
+	ChangeRequestFieldSysCreatedBy                   = "sys_created_by"
+	ChangeRequestFieldSysCreatedOn                   = "sys_created_on"
+	ChangeRequestFieldSysDomain                      = "sys_domain"
+	ChangeRequestFieldSysDomainPath                  = "sys_domain_path"
+	ChangeRequestFieldSysID                          = "sys_id"
+	ChangeRequestFieldSysModCount                    = "sys_mod_count"
+	ChangeRequestFieldSysTags                        = "sys_tags"
+	ChangeRequestFieldSysUpdatedBy                   = "sys_updated_by"
+	ChangeRequestFieldSysUpdatedOn                   = "sys_updated_on"
+	ChangeRequestFieldTaskEffectiveNumber            = "task_effective_number"
+	ChangeRequestFieldTestPlan                       = "test_plan"
+	ChangeRequestFieldTimeWorked                     = "time_worked"
+	ChangeRequestFieldType                           = "type"
+	ChangeRequestFieldUnauthorized                   = "unauthorized"
+	ChangeRequestFieldUniversalRequest               = "universal_request"
+	ChangeRequestFieldUponApproval                   = "upon_approval"
+	ChangeRequestFieldUponReject                     = "upon_reject"
+	ChangeRequestFieldUrgency                        = "urgency"
+	ChangeRequestFieldUserInput                      = "user_input"
+	ChangeRequestFieldWatchList                      = "watch_list"
+	ChangeRequestFieldWorkEnd                        = "work_end"
+	ChangeRequestFieldWorkNotes                      = "work_notes"
+	ChangeRequestFieldWorkNotesList                  = "work_notes_list"
+	ChangeRequestFieldWorkStart                      = "work_start"
+)
+
+// Field names of Incident, for use in ListOptions.Fields, GetOptions.Fields
+// and queries.
+const (
+	IncidentFieldActive                 = "active"
+	IncidentFieldActivityDue            = "activity_due"
+	IncidentFieldAdditionalAssigneeList = "additional_assignee_list"
+	IncidentFieldApproval               = "approval"
+	IncidentFieldApprovalHistory        = "approval_history"
+	IncidentFieldApprovalSet            = "approval_set"
+	IncidentFieldAssignedTo             = "assigned_to"
+	IncidentFieldAssignmentGroup        = "assignment_group"
+	IncidentFieldBusinessDuration       = "business_duration"
+	IncidentFieldBusinessService        = "business_service"
+	IncidentFieldBusinessStc            = "business_stc"
+	IncidentFieldCalendarDuration       = "calendar_duration"
+	IncidentFieldCalendarStc            = "calendar_stc"
+	IncidentFieldCallerID               = "caller_id"
+	IncidentFieldCategory               = "category"
+	IncidentFieldCausedBy               = "caused_by"
+	IncidentFieldChildIncidents         = "child_incidents"
+	IncidentFieldCloseCode              = "close_code"
+	IncidentFieldClosedAt               = "closed_at"
+	IncidentFieldClosedBy               = "closed_by"
+	IncidentFieldCloseNotes             = "close_notes"
+	IncidentFieldCmdbCi                 = "cmdb_ci"
+	IncidentFieldComments               = "comments"
+	IncidentFieldCommentsAndWorkNotes   = "comments_and_work_notes"
+	IncidentFieldCompany                = "company"
+	IncidentFieldContactType            = "contact_type"
+	IncidentFieldCorrelationDisplay     = "correlation_display"
+	IncidentFieldCorrelationID          = "correlation_id"
+	IncidentFieldDeliveryPlan           = "delivery_plan"
+	IncidentFieldDeliveryTask           = "delivery_task"
+	IncidentFieldDescription            = "description"
+	IncidentFieldDueDate                = "due_date"
+	IncidentFieldEscalation             = "escalation"
+	IncidentFieldExpectedStart          = "expected_start"
+	IncidentFieldFollowUp               = "follow_up"
+	IncidentFieldGroupList              = "group_list"
+	IncidentFieldImpact                 = "impact"
+	IncidentFieldIncidentState          = "incident_state"
+	IncidentFieldKnowledge              = "knowledge"
+	IncidentFieldLocation               = "location"
+	IncidentFieldMadeSLA                = "made_sla"
+	IncidentFieldNotify                 = "notify"
+	IncidentFieldNumber                 = "number"
+	IncidentFieldOpenedAt               = "opened_at"
+	IncidentFieldOpenedBy               = "opened_by"
+	IncidentFieldOrder                  = "order"
+	IncidentFieldParent                 = "parent"
+	IncidentFieldParentIncident         = "parent_incident"
+	IncidentFieldPriority               = "priority"
+	IncidentFieldProblemID              = "problem_id"
+	IncidentFieldReassignmentCount      = "reassignment_count"
+	IncidentFieldRejectionGoto          = "rejection_goto"
+	IncidentFieldReopenCount            = "reopen_count"
+	IncidentFieldResolvedAt             = "resolved_at"
+	IncidentFieldResolvedBy             = "resolved_by"
+	IncidentFieldRfc                    = "rfc"
+	IncidentFieldSeverity               = "severity"
+	IncidentFieldShortDescription       = "short_description"
+	IncidentFieldSLADue                 = "sla_due"
+	IncidentFieldState                  = "state"
+	IncidentFieldSubcategory            = "subcategory"
+	IncidentFieldSysClassName           = "sys_class_name"
+	IncidentFieldSysCreatedBy           = "sys_created_by"
+	IncidentFieldSysCreatedOn           = "sys_created_on"
+	IncidentFieldSysDomain              = "sys_domain"
+	IncidentFieldSysDomainPath          = "sys_domain_path"
+	IncidentFieldSysID                  = "sys_id"
+	IncidentFieldSysModCount            = "sys_mod_count"
+	IncidentFieldSysTags                = "sys_tags"
+	IncidentFieldSysUpdatedBy           = "sys_updated_by"
+	IncidentFieldSysUpdatedOn           = "sys_updated_on"
+	IncidentFieldTimeWorked             = "time_worked"
+	IncidentFieldUponApproval           = "upon_approval"
+	IncidentFieldUponReject             = "upon_reject"
+	IncidentFieldUrgency                = "urgency"
+	IncidentFieldUserInput              = "user_input"
+	IncidentFieldWatchList              = "watch_list"
+	IncidentFieldWfActivity             = "wf_activity"
+	IncidentFieldWorkEnd                = "work_end"
+	IncidentFieldWorkNotes              = "work_notes"
+	IncidentFieldWorkNotesList          = "work_notes_list"
+	IncidentFieldWorkStart              = "work_start"
+)
+
+// Field names of StandardChangeTemplate, for use in ListOptions.Fields, GetOptions.Fields
+// and queries.
+const (
+	StandardChangeTemplateFieldActive                         = "active"
+	StandardChangeTemplateFieldActivityDue                    = "activity_due"
+	StandardChangeTemplateFieldAdditionalAssigneeList         = "additional_assignee_list"
+	StandardChangeTemplateFieldApproval                       = "approval"
+	StandardChangeTemplateFieldApprovalHistory                = "approval_history"
+	StandardChangeTemplateFieldApprovalSet                    = "approval_set"
+	StandardChangeTemplateFieldAssignedTo                     = "assigned_to"
+	StandardChangeTemplateFieldAssignmentGroup                = "assignment_group"
+	StandardChangeTemplateFieldBusinessDuration               = "business_duration"
+	StandardChangeTemplateFieldBusinessJustification          = "business_justification"
+	StandardChangeTemplateFieldBusinessService                = "business_service"
+	StandardChangeTemplateFieldCalendarDuration               = "calendar_duration"
+	StandardChangeTemplateFieldCatalog                        = "catalog"
+	StandardChangeTemplateFieldCategory                       = "category"
+	StandardChangeTemplateFieldChangeRequests                 = "change_requests"
+	StandardChangeTemplateFieldClosedAt                       = "closed_at"
+	StandardChangeTemplateFieldClosedBy                       = "closed_by"
+	StandardChangeTemplateFieldCloseNotes                     = "close_notes"
+	StandardChangeTemplateFieldCmdbCi                         = "cmdb_ci"
+	StandardChangeTemplateFieldComments                       = "comments"
+	StandardChangeTemplateFieldCommentsAndWorkNotes           = "comments_and_work_notes"
+	StandardChangeTemplateFieldCompany                        = "company"
+	StandardChangeTemplateFieldContactType                    = "contact_type"
+	StandardChangeTemplateFieldCorrelationDisplay             = "correlation_display"
+	StandardChangeTemplateFieldCorrelationID                  = "correlation_id"
+	StandardChangeTemplateFieldCreatedFromChange              = "created_from_change"
+	StandardChangeTemplateFieldDescription                    = "description"
+	StandardChangeTemplateFieldDueDate                        = "due_date"
+	StandardChangeTemplateFieldEscalation                     = "escalation"
+	StandardChangeTemplateFieldExpectedStart                  = "expected_start"
+	StandardChangeTemplateFieldFollowUp                       = "follow_up"
+	StandardChangeTemplateFieldGroupList                      = "group_list"
+	StandardChangeTemplateFieldImpact                         = "impact"
+	StandardChangeTemplateFieldKnowledge                      = "knowledge"
+	StandardChangeTemplateFieldLocation                       = "location"
+	StandardChangeTemplateFieldMadeSLA                        = "made_sla"
+	StandardChangeTemplateFieldNumber                         = "number"
+	StandardChangeTemplateFieldOpenedAt                       = "opened_at"
+	StandardChangeTemplateFieldOpenedBy                       = "opened_by"
+	StandardChangeTemplateFieldOrder                          = "order"
+	StandardChangeTemplateFieldParent                         = "parent"
+	StandardChangeTemplateFieldPriority                       = "priority"
+	StandardChangeTemplateFieldProposalType                   = "proposal_type"
+	StandardChangeTemplateFieldReassignmentCount              = "reassignment_count"
+	StandardChangeTemplateFieldRouteReason                    = "route_reason"
+	StandardChangeTemplateFieldServiceOffering                = "service_offering"
+	StandardChangeTemplateFieldShortDescription               = "short_description"
+	StandardChangeTemplateFieldSkills                         = "skills"
+	StandardChangeTemplateFieldSLADue                         = "sla_due"
+	StandardChangeTemplateFieldSnEsignDocument                = "sn_esign_document"
+	StandardChangeTemplateFieldSnEsignEsignatureConfiguration = "sn_esign_esignature_configuration"
+	StandardChangeTemplateFieldState                          = "state"
+	StandardChangeTemplateFieldStdChangeProducer              = "std_change_producer"
+	StandardChangeTemplateFieldStdChangeProducerVersion       = "std_change_producer_version"
+	StandardChangeTemplateFieldSysClassName                   = "sys_class_name"
+	StandardChangeTemplateFieldSysCreatedBy                   = "sys_created_by"
+	StandardChangeTemplateFieldSysCreatedOn                   = "sys_created_on"
+	StandardChangeTemplateFieldSysDomain                      = "sys_domain"
+	StandardChangeTemplateFieldSysDomainPath                  = "sys_domain_path"
+	StandardChangeTemplateFieldSysID                          = "sys_id"
+	StandardChangeTemplateFieldSysModCount                    = "sys_mod_count"
+	StandardChangeTemplateFieldSysTags                        = "sys_tags"
+	StandardChangeTemplateFieldSysUpdatedBy                   = "sys_updated_by"
+	StandardChangeTemplateFieldSysUpdatedOn                   = "sys_updated_on"
+	StandardChangeTemplateFieldTaskEffectiveNumber            = "task_effective_number"
+	StandardChangeTemplateFieldTemplateName                   = "template_name"
+	StandardChangeTemplateFieldTemplateValue                  = "template_value"
+	StandardChangeTemplateFieldTimeWorked                     = "time_worked"
+	StandardChangeTemplateFieldUniversalRequest               = "universal_request"
+	StandardChangeTemplateFieldUponApproval                   = "upon_approval"
+	StandardChangeTemplateFieldUponReject                     = "upon_reject"
+	StandardChangeTemplateFieldUrgency                        = "urgency"
+	StandardChangeTemplateFieldUserInput                      = "user_input"
+	StandardChangeTemplateFieldWatchList                      = "watch_list"
+	StandardChangeTemplateFieldWorkEnd                        = "work_end"
+	StandardChangeTemplateFieldWorkNotes                      = "work_notes"
+	StandardChangeTemplateFieldWorkNotesList                  = "work_notes_list"
+	StandardChangeTemplateFieldWorkStart                      = "work_start"
+)
@@ -1,4 +1,5 @@
 //go:generate go run gen-accessors.go
+//go:generate go run gen-fields.go
 
 package servicenow
 
@@ -12,8 +13,10 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -21,6 +24,15 @@ import (
 const (
 	userAgent = "go-servicenow"
 	jsonv2Opt = "JSONv2"
+
+	defaultMediaType = "application/octet-stream"
+
+	headerLink       = "Link"
+	headerTotalCount = "X-Total-Count"
+
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateReset     = "X-RateLimit-Reset"
 )
 
 // A Client manages communication with the ServiceNow API.
@@ -28,18 +40,32 @@ type Client struct {
 	clientMu sync.Mutex   // clientMu protects the client during calls that modify the CheckRedirect func.
 	client   *http.Client // HTTP client used to communicate with the API.
 
+	rateMu    sync.Mutex
+	rateLimit Rate // Rate limit for the client as determined by the most recent API call.
+
 	// Base URL for API requests. BaseURL should always be specified with a trailing slash.
 	BaseURL *url.URL
 
 	// User agent used when communicating with the ServiceNow API.
 	UserAgent string
 
+	// RetryPolicy controls how requests that fail with a transient error are
+	// retried. Requests are not retried if RetryPolicy is nil.
+	RetryPolicy *RetryPolicy
+
+	backend        Backend        // API used by the record services.
+	location       *time.Location // Time zone of the user, in which display values are given.
+	displayLayouts []string       // Layouts of date and time display values.
+	choices        Choices        // Choices overriding DefaultChoices.
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the ServiceNow API.
 	Incidents               *IncidentsService
 	ChangeRequests          *ChangeRequestsService
 	StandardChangeTemplates *StandardChangeTemplatesService
+	Aggregate               *AggregateService
+	Attachments             *AttachmentsService
 }
 
 type service struct {
@@ -60,6 +86,8 @@ const (
 	SysparmActionUpdate         = "update"
 	SysparmActionDelete         = "deleteRecord"
 	SysparmActionDeleteMultiple = "deleteMultiple"
+	SysparmActionGet            = "get"
+	SysparmActionGetKeys        = "getKeys"
 )
 
 type DisplayValueType string
@@ -92,14 +120,50 @@ type ListOptions struct {
 	Limit        string           `url:"sysparm_record_count,omitempty"`
 	DisplayValue DisplayValueType `url:"displayvalue,omitempty"`
 
+	// Query selects the records to list. An encoded query may be set in
+	// SysparmQuery instead, but not together with Query or QueryOpts.
+	Query *Query `url:"-"`
+
+	// QueryOpts are conditions ANDed to Query.
+	//
+	// Deprecated: Use Query, which supports more operators.
 	QueryOpts []QueryOpts `url:"-"`
 
+	// Offset skips the first Offset matching records. Offset pagination is
+	// only supported by the Table API backend.
+	Offset int `url:"-"`
+
+	// Keyset orders records by sys_id, so that the next page can be requested
+	// with AfterSysID. Keyset pagination is supported by both backends and is
+	// not affected by records being inserted or deleted between pages.
+	Keyset bool `url:"-"`
+
+	// AfterSysID only lists the records whose sys_id sorts after AfterSysID.
+	// It implies Keyset.
+	AfterSysID string `url:"-"`
+
+	// Fields restricts the returned fields to those listed, such as
+	// IncidentFieldNumber. sys_id is added for keyset pagination. Fields is
+	// only supported by the Table API backend; JSONv2 returns all fields.
+	Fields []string `url:"-"`
+
+	// View restricts the returned fields to those of a UI view, such as
+	// "mobile".
+	View string `url:"sysparm_view,omitempty"`
+
 	internalFields
 }
 
 type GetOptions struct {
 	DisplayValue DisplayValueType `url:"displayvalue,omitempty"`
 
+	// Fields restricts the returned fields to those listed. It is only
+	// supported by the Table API backend.
+	Fields []string `url:"-"`
+
+	// View restricts the returned fields to those of a UI view.
+	View string `url:"sysparm_view,omitempty"`
+
 	internalFields
 }
 
@@ -115,6 +179,28 @@ type UpdateOptions struct {
 	internalFields
 }
 
+type CreateManyOptions struct {
+	// ChunkSize is the maximum number of records inserted per insertMultiple
+	// request. It defaults to 100. It is ignored by the Table API backend,
+	// which inserts records one by one.
+	ChunkSize int `url:"-"`
+
+	DisplayValue DisplayValueType `url:"displayvalue,omitempty"`
+
+	internalFields
+}
+
+type DeleteOptions struct {
+	// DryRun counts the records that match the query without deleting them.
+	DryRun bool `url:"-"`
+
+	// MaxRecords, if positive, is the maximum number of records a query may
+	// match. If more records match, none are deleted and an error is returned.
+	MaxRecords int `url:"-"`
+
+	internalFields
+}
+
 // addOptions adds the parameters in opts as URL query parameters to s. opts
 // must be a struct whose fields may contain "url" tags.
 func addOptions(s string, opts interface{}) (string, error) {
@@ -142,8 +228,10 @@ func addOptions(s string, opts interface{}) (string, error) {
 // NewClient returns a new ServiceNow API client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide a http.Client that will perform the authentication
-// for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
+// for you (such as that provided by BasicAuthTransport or OAuthTransport).
+// The record services use the legacy JSONv2 processor unless another backend
+// is selected with WithBackend.
+func NewClient(baseURL string, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
@@ -157,10 +245,15 @@ func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
 	}
 
 	c := &Client{client: httpClient, BaseURL: baseEndpoint, UserAgent: userAgent}
+	for _, opt := range opts {
+		opt(c)
+	}
 	c.common.client = c
-	c.Incidents = (*IncidentsService)(&c.common)
-	c.ChangeRequests = (*ChangeRequestsService)(&c.common)
-	c.StandardChangeTemplates = (*StandardChangeTemplatesService)(&c.common)
+	c.Aggregate = (*AggregateService)(&c.common)
+	c.Attachments = (*AttachmentsService)(&c.common)
+	c.Incidents = &IncidentsService{NewTableService[Incident](c, IncidentTable)}
+	c.ChangeRequests = &ChangeRequestsService{NewTableService[ChangeRequest](c, ChangeRequestTable)}
+	c.StandardChangeTemplates = &StandardChangeTemplatesService{NewTableService[StandardChangeTemplate](c, StandardChangeTemplateTable)}
 	return c, nil
 }
 
@@ -204,83 +297,486 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	return req, nil
 }
 
+// NewUploadRequest creates a request to upload the content of reader as the
+// body of a POST request to urlStr, which is resolved relative to the BaseURL
+// of the Client like in NewRequest. The content is streamed rather than
+// buffered. size is the length of the content, or -1 if it is unknown, in
+// which case it is sent with chunked encoding unless reader is a
+// *bytes.Buffer, *bytes.Reader or *strings.Reader. mediaType is the content
+// type of the content, and defaults to application/octet-stream.
+func (c *Client) NewUploadRequest(urlStr string, reader io.Reader, size int64, mediaType string) (*http.Request, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+	u, err := c.BaseURL.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	if mediaType == "" {
+		mediaType = defaultMediaType
+	}
+	req.Header.Set("Content-Type", mediaType)
+	req.Header.Set("Accept", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	return req, nil
+}
+
 // Response is a ServiceNow API response. This wraps the standard http.Response
 // returned from ServiceNow and provides convenient access to things like
 // pagination links.
 type Response struct {
 	*http.Response
+
+	// These fields provide the page values for paginating through a set of
+	// results. NextOffset is set for offset pagination and NextSysID for
+	// keyset pagination; they are zero when there are no more pages.
+	NextOffset int
+	NextSysID  string
+
+	// TotalCount is the total number of records matching a list request, as
+	// reported by the Table API. It is zero if unknown.
+	TotalCount int
+
+	// Explicitly specify the Rate type so Rate's String() receiver doesn't
+	// propagate to Response.
+	Rate Rate
 }
 
 // newResponse creates a new Response for the provided http.Response.
 // r must not be nil.
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
+	response.populatePageValues()
+	response.Rate = parseRate(r)
 	return response
 }
 
-func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+// populatePageValues parses the Link and X-Total-Count headers returned by
+// the Table API and populates the NextOffset and TotalCount fields.
+func (r *Response) populatePageValues() {
+	if total := r.Header.Get(headerTotalCount); total != "" {
+		r.TotalCount, _ = strconv.Atoi(total)
+	}
+
+	for _, link := range strings.Split(r.Header.Get(headerLink), ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+
+		// link must at least have href and rel
+		if len(segments) < 2 {
+			continue
+		}
+
+		// ensure href is properly formatted
+		if !strings.HasPrefix(segments[0], "<") || !strings.HasSuffix(segments[0], ">") {
+			continue
+		}
+
+		// try to pull out offset parameter
+		u, err := url.Parse(segments[0][1 : len(segments[0])-1])
+		if err != nil {
+			continue
+		}
+		offset := u.Query().Get("sysparm_offset")
+		if offset == "" {
+			continue
+		}
+
+		for _, segment := range segments[1:] {
+			if strings.TrimSpace(segment) == `rel="next"` {
+				r.NextOffset, _ = strconv.Atoi(offset)
+			}
+		}
+	}
+}
+
+// parseRate parses the rate related headers.
+func parseRate(r *http.Response) Rate {
+	var rate Rate
+	if limit := r.Header.Get(headerRateLimit); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := r.Header.Get(headerRateRemaining); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+	if reset := r.Header.Get(headerRateReset); reset != "" {
+		if v, _ := strconv.ParseInt(reset, 10, 64); v != 0 {
+			rate.Reset = Timestamp{time.Unix(v, 0)}
+		}
+	}
+	return rate
+}
+
+// Rate represents the rate limit a ServiceNow rate limit rule applies to the
+// client. It is only populated when the instance has such a rule configured.
+type Rate struct {
+	// The number of requests per hour the client is currently limited to.
+	Limit int `json:"limit"`
+
+	// The number of remaining requests the client can make this hour.
+	Remaining int `json:"remaining"`
+
+	// The time at which the current rate limit will reset.
+	Reset Timestamp `json:"reset"`
+}
+
+func (r Rate) String() string {
+	return Stringify(r)
+}
+
+// RateLimits returns the rate limit for the client as reported by the most
+// recent API response that carried rate limit headers. The zero Rate is
+// returned if no such response has been received yet.
+func (c *Client) RateLimits() Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateLimit
+}
+
+// updateRateLimit records the rate limit reported by response, if any.
+func (c *Client) updateRateLimit(response *Response) {
+	if response.Rate.Limit == 0 {
+		return
+	}
+	c.rateMu.Lock()
+	c.rateLimit = response.Rate
+	c.rateMu.Unlock()
+}
+
+// checkRateLimitBeforeDo does not make any network calls, but uses existing
+// knowledge from the current client state in order to quickly check if a
+// *RateLimitError can be immediately returned from Client.Do, and if so,
+// returns it so that Client.Do can skip making a network API call
+// unnecessarily. Otherwise it returns nil, and Client.Do should proceed
+// normally.
+func (c *Client) checkRateLimitBeforeDo(req *http.Request) *RateLimitError {
+	rate := c.RateLimits()
+	if rate.Limit == 0 || rate.Remaining > 0 || !time.Now().Before(rate.Reset.Time) {
+		return nil
+	}
+
+	// Create a fake response.
+	resp := &http.Response{
+		Status:     http.StatusText(http.StatusTooManyRequests),
+		StatusCode: http.StatusTooManyRequests,
+		Request:    req,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	return &RateLimitError{
+		Rate:     rate,
+		Response: resp,
+		Message:  fmt.Sprintf("API rate limit of %v still exceeded until %v, not making remote request.", rate.Limit, rate.Reset.Time),
+	}
+}
+
+// BareDo sends an API request and lets you handle the api response. If an error
+// or API Error occurs, the error will contain more information. Otherwise you
+// are supposed to read and close the response's Body. If a RetryPolicy is set,
+// requests that fail with a transient error are retried according to it.
+//
+// The provided ctx must be non-nil, if it is nil an error is returned. If it is
+// canceled or times out, ctx.Err() will be returned.
+func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		// If we got an error, and the context has been canceled,
-		// the context's error is probably more useful.
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+	// If we've hit the rate limit, don't make further requests before Reset time.
+	if err := c.checkRateLimitBeforeDo(req); err != nil {
+		return &Response{
+			Response: err.Response,
+			Rate:     err.Rate,
+		}, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.client.Do(req)
+		if err != nil {
+			// If we got an error, and the context has been canceled,
+			// the context's error is probably more useful.
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			if wait, ok := c.RetryPolicy.retryWait(req, nil, err, attempt); ok {
+				if err := prepareRetry(ctx, req, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			// If the error type is *url.Error, sanitize its URL before returning.
+			if e, ok := err.(*url.Error); ok {
+				if url, err := url.Parse(e.URL); err == nil {
+					e.URL = sanitizeURL(url).String()
+					return nil, e
+				}
+			}
+
+			return nil, err
 		}
 
-		// If the error type is *url.Error, sanitize its URL before returning.
-		if e, ok := err.(*url.Error); ok {
-			if url, err := url.Parse(e.URL); err == nil {
-				e.URL = sanitizeURL(url).String()
-				return nil, e
+		response := newResponse(resp)
+		c.updateRateLimit(response)
+
+		if wait, ok := c.RetryPolicy.retryWait(req, resp, nil, attempt); ok {
+			discardBody(resp)
+			if err := prepareRetry(ctx, req, wait); err != nil {
+				return nil, err
 			}
+			continue
 		}
 
-		return nil, err
+		err = CheckResponse(resp)
+		if err != nil {
+			defer resp.Body.Close()
+		}
+
+		return response, err
 	}
+}
 
+// Do sends an API request and returns the API response. The API response is
+// JSON decoded and stored in the value pointed to by v, or returned as an
+// error if an API error has occurred. If v implements the io.Writer interface,
+// the raw response body will be written to v, without attempting to first
+// decode it, and any error copying it is returned.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	resp, err := c.BareDo(ctx, req)
+	if err != nil {
+		return resp, err
+	}
 	defer resp.Body.Close()
 
-	response := newResponse(resp)
-
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
+			_, err = io.Copy(w, resp.Body)
 		} else {
-			decErr := json.NewDecoder(resp.Body).Decode(v)
+			var raw json.RawMessage
+			decErr := json.NewDecoder(resp.Body).Decode(&raw)
 			if decErr == io.EOF {
-				decErr = nil // ignore EOF errors caused by empty response body
+				return resp, nil // ignore EOF errors caused by empty response body
 			}
 			if decErr != nil {
-				err = decErr
+				return resp, decErr
+			}
+			if err := checkErrorPayload(resp.Response, raw); err != nil {
+				return resp, err
 			}
+			err = json.Unmarshal(raw, v)
+		}
+	}
+
+	return resp, err
+}
+
+// ErrorResponse reports an error caused by an API request. It is returned for
+// non-2xx responses as well as for JSONv2 responses that carry an error
+// payload despite a successful status code.
+type ErrorResponse struct {
+	Response *http.Response // HTTP response that caused this error
+	Method   string         // HTTP method of the request
+	URL      string         // Request URL with sensitive parameters redacted
+
+	Err    ErrorDetail `json:"error"`  // Error returned by ServiceNow
+	Status string      `json:"status"` // Status returned by ServiceNow, typically "failure"
+}
+
+func (r *ErrorResponse) Error() string {
+	msg := r.Err.Message
+	if r.Err.Detail != "" {
+		msg += ": " + r.Err.Detail
+	}
+	return fmt.Sprintf("%v %v: %d %v", r.Method, r.URL, r.Response.StatusCode, msg)
+}
+
+// ErrorDetail is the error object returned by ServiceNow. The Table API
+// returns an object with a message and a detail, while the legacy JSONv2
+// processor returns the message as a bare string.
+type ErrorDetail struct {
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The error is expected either as a string or as an object.
+func (e *ErrorDetail) UnmarshalJSON(data []byte) error {
+	var msg string
+	if err := json.Unmarshal(data, &msg); err == nil {
+		e.Message = msg
+		return nil
+	}
+	type errorDetail ErrorDetail
+	return json.Unmarshal(data, (*errorDetail)(e))
+}
+
+// CheckResponse checks the API response for errors, and returns them if
+// present. A response is considered an error if it has a status code outside
+// the 200 range. API error responses are expected to have either no response
+// body, or a JSON response body that maps to ErrorResponse. Any other
+// response body will be silently ignored.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+	errorResponse := newErrorResponse(r)
+	data, err := io.ReadAll(r.Body)
+	if err == nil && data != nil {
+		json.Unmarshal(data, errorResponse)
+	}
+	if errorResponse.Err.Message == "" {
+		errorResponse.Err.Message = http.StatusText(r.StatusCode)
+	}
+	if r.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			Rate:     parseRate(r),
+			Response: errorResponse.Response,
+			Message:  errorResponse.Err.Message,
+		}
+	}
+	return errorResponse
+}
+
+// checkErrorPayload reports the error carried by a successful JSONv2
+// response body, if any.
+func checkErrorPayload(r *http.Response, data json.RawMessage) error {
+	var payload struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil || len(payload.Error) == 0 || string(payload.Error) == "null" {
+		return nil
+	}
+	errorResponse := newErrorResponse(r)
+	json.Unmarshal(data, errorResponse)
+	return errorResponse
+}
+
+func newErrorResponse(r *http.Response) *ErrorResponse {
+	errorResponse := &ErrorResponse{Response: r}
+	if r.Request != nil {
+		errorResponse.Method = r.Request.Method
+		if r.Request.URL != nil {
+			u := *r.Request.URL
+			errorResponse.URL = sanitizeURL(&u).String()
 		}
 	}
+	return errorResponse
+}
 
-	return response, err
+// RateLimitError occurs when ServiceNow returns 429 Too Many Requests, or when
+// the client knows from a previous response that its rate limit is exhausted.
+type RateLimitError struct {
+	Rate     Rate           // Rate specifies last known rate limit for the client
+	Response *http.Response // HTTP response that caused this error
+	Message  string         `json:"message"` // error message
 }
 
-// sanitizeURL redacts the client_secret parameter from the URL which may be
-// exposed to the user.
+func (r *RateLimitError) Error() string {
+	u := *r.Response.Request.URL
+	msg := fmt.Sprintf("%v %v: %d %v",
+		r.Response.Request.Method, sanitizeURL(&u),
+		r.Response.StatusCode, r.Message)
+	if r.Rate.Reset.IsZero() {
+		return msg
+	}
+	return msg + " " + formatRateReset(time.Until(r.Rate.Reset.Time))
+}
+
+// formatRateReset formats d to look like "[rate reset in 2s]" or
+// "[rate reset in 87m02s]" for the positive durations. And like "[rate limit was reset 87m02s ago]"
+// for the negative cases.
+func formatRateReset(d time.Duration) string {
+	isNegative := d < 0
+	if isNegative {
+		d *= -1
+	}
+	secondsTotal := int(0.5 + d.Seconds())
+	minutes := secondsTotal / 60
+	seconds := secondsTotal - minutes*60
+
+	var timeString string
+	if minutes > 0 {
+		timeString = fmt.Sprintf("%dm%02ds", minutes, seconds)
+	} else {
+		timeString = fmt.Sprintf("%ds", seconds)
+	}
+
+	if isNegative {
+		return fmt.Sprintf("[rate limit was reset %v ago]", timeString)
+	}
+	return fmt.Sprintf("[rate reset in %v]", timeString)
+}
+
+// IsRateLimited reports whether err is a RateLimitError.
+func IsRateLimited(err error) bool {
+	var e *RateLimitError
+	return errors.As(err, &e)
+}
+
+// ErrRecordNotFound is returned when the record an operation targets does
+// not exist.
+var ErrRecordNotFound = errors.New("record not found")
+
+// IsNotFound reports whether err is an ErrorResponse for a 404 Not Found
+// response, or wraps ErrRecordNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrRecordNotFound) || hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an ErrorResponse for a 401 Unauthorized response.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an ErrorResponse for a 403 Forbidden response.
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+func hasStatusCode(err error, code int) bool {
+	var e *ErrorResponse
+	return errors.As(err, &e) && e.Response != nil && e.Response.StatusCode == code
+}
+
+// sensitiveParams lists the URL parameters redacted by sanitizeURL.
+var sensitiveParams = []string{"client_secret", "password", "refresh_token"}
+
+// sanitizeURL redacts the client_secret, password and refresh_token
+// parameters from the URL which may be exposed to the user.
 func sanitizeURL(uri *url.URL) *url.URL {
 	if uri == nil {
 		return nil
 	}
 	params := uri.Query()
-	if len(params.Get("client_secret")) > 0 {
-		params.Set("client_secret", "REDACTED")
+	var redacted bool
+	for _, p := range sensitiveParams {
+		if len(params.Get(p)) > 0 {
+			params.Set(p, "REDACTED")
+			redacted = true
+		}
+	}
+	if redacted {
 		uri.RawQuery = params.Encode()
 	}
 	return uri
 }
 
-func setCredentialsAsHeaders(req *http.Request, id, secret string) *http.Request {
+// cloneRequest returns a copy of req with a deep copy of its headers.
+func cloneRequest(req *http.Request) *http.Request {
 	// To set extra headers, we must make a copy of the Request so
 	// that we don't modify the Request we were given. This is required by the
 	// specification of http.RoundTripper.
@@ -294,6 +790,11 @@ func setCredentialsAsHeaders(req *http.Request, id, secret string) *http.Request
 	for k, s := range req.Header {
 		convertedRequest.Header[k] = append([]string(nil), s...)
 	}
+	return convertedRequest
+}
+
+func setCredentialsAsHeaders(req *http.Request, id, secret string) *http.Request {
+	convertedRequest := cloneRequest(req)
 	convertedRequest.SetBasicAuth(id, secret)
 	return convertedRequest
 }
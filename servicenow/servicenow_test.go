@@ -0,0 +1,276 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setup sets up a test HTTP server along with a Client that is configured to
+// talk to that test server. Tests register handlers on mux which provide
+// mock responses for the API method being tested.
+func setup(t *testing.T, opts ...ClientOption) (*Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, nil, opts...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return client, mux
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	if got := r.Method; got != want {
+		t.Errorf("Request method: %v, want %v", got, want)
+	}
+}
+
+// testQuery checks the sysparm_query parameter of r.
+func testQuery(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	if got := r.URL.Query().Get("sysparm_query"); got != want {
+		t.Errorf("sysparm_query = %q, want %q", got, want)
+	}
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+}
+
+// records returns the JSONv2 envelope holding records.
+func records(records ...map[string]interface{}) map[string]interface{} {
+	if records == nil {
+		records = []map[string]interface{}{}
+	}
+	return map[string]interface{}{"records": records}
+}
+
+// result returns the Table API envelope holding v.
+func result(v interface{}) map[string]interface{} {
+	return map[string]interface{}{"result": v}
+}
+
+func TestDo_errorResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   ErrorDetail
+	}{
+		{
+			name:   "table api",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"message":"Invalid query","detail":"bad field"},"status":"failure"}`,
+			want:   ErrorDetail{Message: "Invalid query", Detail: "bad field"},
+		},
+		{
+			name:   "no body",
+			status: http.StatusInternalServerError,
+			want:   ErrorDetail{Message: "Internal Server Error"},
+		},
+		{
+			name:   "jsonv2 error payload",
+			status: http.StatusOK,
+			body:   `{"error":"Insufficient rights"}`,
+			want:   ErrorDetail{Message: "Insufficient rights"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			req, err := client.NewRequest("GET", "incident.do?password=secret", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			_, err = client.Do(context.Background(), req, new(json.RawMessage))
+			var e *ErrorResponse
+			if !errors.As(err, &e) {
+				t.Fatalf("Do returned error %v, want *ErrorResponse", err)
+			}
+			if e.Method != "GET" {
+				t.Errorf("Method = %q, want GET", e.Method)
+			}
+			if want := client.BaseURL.String() + "incident.do?password=REDACTED"; e.URL != want {
+				t.Errorf("URL = %q, want %q", e.URL, want)
+			}
+			if e.Response == nil || e.Response.StatusCode != tt.status {
+				t.Errorf("Response = %v, want status %d", e.Response, tt.status)
+			}
+			if e.Err != tt.want {
+				t.Errorf("Err = %+v, want %+v", e.Err, tt.want)
+			}
+			if strings.Contains(e.Error(), "secret") {
+				t.Errorf("Error() = %q, leaks the password", e.Error())
+			}
+		})
+	}
+}
+
+func TestIsStatus(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest} {
+		client, mux := setup(t)
+		mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		})
+		req, _ := client.NewRequest("GET", "incident.do", nil)
+		_, err := client.Do(context.Background(), req, nil)
+		if got := IsUnauthorized(err); got != (status == http.StatusUnauthorized) {
+			t.Errorf("status %d: IsUnauthorized = %v", status, got)
+		}
+		if got := IsForbidden(err); got != (status == http.StatusForbidden) {
+			t.Errorf("status %d: IsForbidden = %v", status, got)
+		}
+		if got := IsNotFound(err); got != (status == http.StatusNotFound) {
+			t.Errorf("status %d: IsNotFound = %v", status, got)
+		}
+	}
+
+	if !IsNotFound(fmt.Errorf("incident INC1: %w", ErrRecordNotFound)) {
+		t.Errorf("IsNotFound of a wrapped ErrRecordNotFound = false, want true")
+	}
+	if IsNotFound(errors.New("other")) || IsUnauthorized(nil) {
+		t.Errorf("IsNotFound or IsUnauthorized reported an unrelated error")
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	reset := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   Rate
+	}{
+		{"none", nil, Rate{}},
+		{
+			name: "all",
+			header: map[string]string{
+				headerRateLimit:     "100",
+				headerRateRemaining: "42",
+				headerRateReset:     strconv.FormatInt(reset.Unix(), 10),
+			},
+			want: Rate{Limit: 100, Remaining: 42, Reset: Timestamp{reset}},
+		},
+		{
+			name:   "invalid",
+			header: map[string]string{headerRateLimit: "many", headerRateRemaining: "10", headerRateReset: "soon"},
+			want:   Rate{Remaining: 10},
+		},
+	}
+	for _, tt := range tests {
+		header := make(http.Header)
+		for k, v := range tt.header {
+			header.Set(k, v)
+		}
+		got := parseRate(&http.Response{Header: header})
+		if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining || !got.Reset.Equal(tt.want.Reset) {
+			t.Errorf("%s: parseRate = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RateLimits(t *testing.T) {
+	client, mux := setup(t)
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	remaining := 1
+	requests := 0
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set(headerRateLimit, "100")
+		w.Header().Set(headerRateRemaining, strconv.Itoa(remaining))
+		w.Header().Set(headerRateReset, strconv.FormatInt(reset.Unix(), 10))
+		writeJSON(t, w, records())
+	})
+	mux.HandleFunc("/change_request.do", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, records())
+	})
+	ctx := context.Background()
+
+	if got := client.RateLimits(); got.Limit != 0 {
+		t.Errorf("RateLimits before any request = %v, want zero", got)
+	}
+	_, resp, err := client.Incidents.List(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.Rate.Limit != 100 || resp.Rate.Remaining != 1 || !resp.Rate.Reset.Equal(Timestamp{reset}) {
+		t.Errorf("Response.Rate = %v", resp.Rate)
+	}
+	if got := client.RateLimits(); got.Limit != 100 || got.Remaining != 1 {
+		t.Errorf("RateLimits = %v, want the rate of the last response", got)
+	}
+
+	// A response without rate limit headers leaves the known rate unchanged.
+	if _, _, err := client.ChangeRequests.List(ctx, ListOptions{}); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := client.RateLimits(); got.Remaining != 1 {
+		t.Errorf("RateLimits after a response without headers = %v, want Remaining 1", got)
+	}
+
+	remaining = 0
+	if _, _, err := client.Incidents.List(ctx, ListOptions{}); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := client.RateLimits(); got.Remaining != 0 {
+		t.Errorf("RateLimits = %v, want Remaining 0", got)
+	}
+
+	// The limit is exhausted until reset, so no request is sent.
+	_, resp, err = client.Incidents.List(ctx, ListOptions{})
+	var e *RateLimitError
+	if !errors.As(err, &e) || !IsRateLimited(err) {
+		t.Fatalf("List returned error %v, want *RateLimitError", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3 as the last one was not sent", requests)
+	}
+	if e.Rate.Remaining != 0 || !e.Rate.Reset.Equal(Timestamp{reset}) {
+		t.Errorf("RateLimitError.Rate = %v", e.Rate)
+	}
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Response = %v, want a 429 response", resp)
+	}
+	if !strings.Contains(e.Error(), "rate reset in") {
+		t.Errorf("Error() = %q, want the time until reset", e.Error())
+	}
+}
+
+func TestCheckResponse_tooManyRequests(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "100")
+		w.Header().Set(headerRateRemaining, "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit exceeded"},"status":"failure"}`))
+	})
+	_, _, err := client.Incidents.List(context.Background(), ListOptions{})
+	var e *RateLimitError
+	if !errors.As(err, &e) {
+		t.Fatalf("List returned error %v, want *RateLimitError", err)
+	}
+	if e.Message != "Rate limit exceeded" || e.Rate.Limit != 100 {
+		t.Errorf("RateLimitError = %+v", e)
+	}
+}
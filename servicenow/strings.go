@@ -7,13 +7,23 @@ package servicenow
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"reflect"
 )
 
-var timestampType = reflect.TypeOf(Timestamp{})
+var (
+	timestampType  = reflect.TypeOf(Timestamp{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// fieldValuer is implemented by Field values.
+type fieldValuer interface {
+	fieldValue() (interface{}, bool)
+}
 
 // Stringify attempts to create a reasonable string representation of types in
 // the GitHub library. It does things like resolve pointers to their values
@@ -39,6 +49,12 @@ func stringifyValue(w io.Writer, val reflect.Value) {
 	case reflect.String:
 		fmt.Fprintf(w, `"%s"`, v)
 	case reflect.Slice:
+		// special handling of raw JSON values, such as those in Extra
+		if v.Type() == rawMessageType {
+			w.Write(v.Bytes())
+			return
+		}
+
 		w.Write([]byte{'['})
 		for i := 0; i < v.Len(); i++ {
 			if i > 0 {
@@ -51,6 +67,16 @@ func stringifyValue(w io.Writer, val reflect.Value) {
 		w.Write([]byte{']'})
 		return
 	case reflect.Struct:
+		// special handling of Field values, which print as their value
+		if f, ok := v.Interface().(fieldValuer); ok {
+			if fv, valid := f.fieldValue(); valid {
+				stringifyValue(w, reflect.ValueOf(fv))
+			} else {
+				w.Write([]byte("null"))
+			}
+			return
+		}
+
 		if v.Type().Name() != "" {
 			w.Write([]byte(v.Type().String()))
 		}
@@ -88,6 +114,20 @@ func stringifyValue(w io.Writer, val reflect.Value) {
 		}
 
 		w.Write([]byte{'}'})
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+		w.Write([]byte("map["))
+		for i, k := range keys {
+			if i > 0 {
+				w.Write([]byte{' '})
+			}
+			fmt.Fprint(w, k.Interface())
+			w.Write([]byte{':'})
+			stringifyValue(w, v.MapIndex(k))
+		}
+		w.Write([]byte{']'})
 	default:
 		if v.CanInterface() {
 			fmt.Fprint(w, v.Interface())
@@ -0,0 +1,659 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// tableAPIPath is the path of the REST Table API, relative to the instance.
+const tableAPIPath = "/api/now/table/"
+
+// Backend selects the ServiceNow API used by the record services.
+type Backend int
+
+const (
+	// BackendJSONv2 uses the legacy JSONv2 processor, e.g. /incident.do?JSONv2.
+	BackendJSONv2 Backend = iota
+
+	// BackendTable uses the REST Table API, e.g. /api/now/table/incident.
+	BackendTable
+)
+
+// A ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithBackend returns a ClientOption that makes the record services talk to
+// backend. Clients use BackendJSONv2 by default.
+func WithBackend(backend Backend) ClientOption {
+	return func(c *Client) {
+		c.backend = backend
+	}
+}
+
+// WithLocation returns a ClientOption that sets the time zone of the user the
+// client authenticates as. Date and time display values, returned when
+// DisplayValue is "true", are given in that time zone, and are parsed in it
+// rather than in UTC.
+func WithLocation(loc *time.Location) ClientOption {
+	return func(c *Client) {
+		c.location = loc
+	}
+}
+
+// WithDisplayLayout returns a ClientOption that sets the layouts, in the
+// format of time.Parse, of the date and time display values returned when
+// DisplayValue is "true", for users whose date format is not the default
+// "2006-01-02 15:04:05", such as "01/02/2006 03:04:05 PM" and "01/02/2006".
+// Display values are parsed with the first layout that matches them, and
+// otherwise with the default layouts. Display values that match no layout are
+// left unset rather than failing the request.
+func WithDisplayLayout(layouts ...string) ClientOption {
+	return func(c *Client) {
+		c.displayLayouts = layouts
+	}
+}
+
+// tableOptions holds the query parameters understood by the Table API.
+type tableOptions struct {
+	Query        string           `url:"sysparm_query,omitempty"`
+	Limit        string           `url:"sysparm_limit,omitempty"`
+	Offset       int              `url:"sysparm_offset,omitempty"`
+	DisplayValue DisplayValueType `url:"sysparm_display_value,omitempty"`
+	Fields       string           `url:"sysparm_fields,omitempty"`
+	View         string           `url:"sysparm_view,omitempty"`
+}
+
+// recordsEnvelope is the envelope around records returned by either backend.
+// JSONv2 always returns an array of records, while the Table API returns a
+// single object for requests that target one record.
+type recordsEnvelope struct {
+	Records json.RawMessage `json:"records"`
+	Result  json.RawMessage `json:"result"`
+
+	display        bool     // Whether the records hold display values
+	displayLayouts []string // Layouts of date and time display values
+}
+
+// data returns the records of the envelope as a JSON array.
+func (e *recordsEnvelope) data() []byte {
+	data := e.Result
+	if len(data) == 0 {
+		data = e.Records
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	return data
+}
+
+// decode stores the records of the envelope in v, which must be a pointer to
+// a slice. Records are decoded with UnmarshalRecord.
+func (e *recordsEnvelope) decode(v interface{}) error {
+	data := e.data()
+	if data == nil || v == nil {
+		return nil
+	}
+
+	var raw []json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	slice := reflect.ValueOf(v).Elem()
+	elemType := slice.Type().Elem()
+	records := reflect.MakeSlice(slice.Type(), len(raw), len(raw))
+	for i, r := range raw {
+		if e.display {
+			if r, err = parseDisplayTimes(r, elemType, e.displayLayouts); err != nil {
+				return err
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			record := reflect.New(elemType.Elem())
+			if err := UnmarshalRecord(r, record.Interface()); err != nil {
+				return err
+			}
+			records.Index(i).Set(record)
+			continue
+		}
+		if err := UnmarshalRecord(r, records.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	slice.Set(records)
+	return nil
+}
+
+// page returns the number of records in the envelope and the sys_id of the
+// last one.
+func (e *recordsEnvelope) page() (int, string, error) {
+	data := e.data()
+	if data == nil {
+		return 0, "", nil
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return 0, "", err
+	}
+	if len(records) == 0 {
+		return 0, "", nil
+	}
+	var last struct {
+		SysID json.RawMessage `json:"sys_id"`
+	}
+	if err := json.Unmarshal(records[len(records)-1], &last); err != nil {
+		return 0, "", err
+	}
+	return len(records), rawSysID(last.SysID), nil
+}
+
+// rawSysID returns the sys_id encoded in data, either as a string or as an
+// object holding the value and display value of the field.
+func rawSysID(data json.RawMessage) string {
+	var sysID string
+	if err := json.Unmarshal(data, &sysID); err == nil {
+		return sysID
+	}
+	var pair struct {
+		Value string `json:"value"`
+	}
+	json.Unmarshal(data, &pair)
+	return pair.Value
+}
+
+// tableURL returns the URL of table, or of the record sysID in table if sysID
+// is not empty, for the configured backend.
+func (c *Client) tableURL(table, sysID string) string {
+	if c.backend != BackendTable {
+		return fmt.Sprintf("/%s.do", table)
+	}
+	u := tableAPIPath + url.PathEscape(table)
+	if sysID != "" {
+		u += "/" + url.PathEscape(sysID)
+	}
+	return u
+}
+
+// addTableOptions adds the parameters in opts as URL query parameters to s.
+func addTableOptions(s string, opts tableOptions) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+	qs, err := query.Values(opts)
+	if err != nil {
+		return s, err
+	}
+	u.RawQuery = qs.Encode()
+	return u.String(), nil
+}
+
+// selectFields returns the value of sysparm_fields for fields, adding sys_id
+// if it is needed for keyset pagination.
+func selectFields(fields []string, keyset bool) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+	hasSysID := false
+	for _, f := range fields {
+		if err := validateQueryField(f); err != nil {
+			return "", err
+		}
+		hasSysID = hasSysID || f == "sys_id"
+	}
+	if keyset && !hasSysID {
+		fields = append(fields[:len(fields):len(fields)], "sys_id")
+	}
+	return strings.Join(fields, ","), nil
+}
+
+// doRecords sends a request to the records endpoint u and stores the returned
+// records in v, which must be a pointer to a slice.
+func (c *Client) doRecords(ctx context.Context, method, u string, body, v interface{}) (*Response, error) {
+	_, resp, err := c.doEnvelope(ctx, method, u, body, v)
+	return resp, err
+}
+
+// doEnvelope is like doRecords, but also returns the envelope of the records.
+func (c *Client) doEnvelope(ctx context.Context, method, u string, body, v interface{}) (*recordsEnvelope, *Response, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := recordsEnvelope{display: displaysValues(req.URL), displayLayouts: c.displayLayouts}
+	resp, err := c.Do(ctx, req, &res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if err := res.decode(v); err != nil {
+		return &res, resp, err
+	}
+	if v != nil && res.display {
+		fromDisplayValues(reflect.ValueOf(v))
+		if c.location != nil {
+			localizeValues(reflect.ValueOf(v), c.location)
+		}
+	}
+	return &res, resp, nil
+}
+
+// displaysValues reports whether u requests display values instead of values.
+func displaysValues(u *url.URL) bool {
+	q := u.Query()
+	return q.Get("displayvalue") == string(DisplayValueTrue) || q.Get("sysparm_display_value") == string(DisplayValueTrue)
+}
+
+// listRecords lists the records of table that match opts, and populates the
+// pagination fields of the returned Response.
+func (c *Client) listRecords(ctx context.Context, table string, opts ListOptions, v interface{}) (*Response, error) {
+	if opts.Offset > 0 && c.backend != BackendTable {
+		return nil, errors.New("offset pagination is not supported by the JSONv2 backend, use keyset pagination instead")
+	}
+
+	keyset := opts.Keyset || opts.AfterSysID != ""
+	if keyset {
+		q, err := keysetQuery(opts.SysparmQuery, opts.AfterSysID)
+		if err != nil {
+			return nil, err
+		}
+		opts.SysparmQuery = q
+	}
+
+	fields, err := selectFields(opts.Fields, keyset)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.tableURL(table, "")
+	if c.backend == BackendTable {
+		u, err = addTableOptions(u, tableOptions{
+			Query:        opts.SysparmQuery,
+			Limit:        opts.Limit,
+			Offset:       opts.Offset,
+			DisplayValue: opts.DisplayValue,
+			Fields:       fields,
+			View:         opts.View,
+		})
+	} else {
+		u, err = addOptions(u, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, resp, err := c.doEnvelope(ctx, "GET", u, nil, v)
+	if err != nil {
+		return resp, err
+	}
+
+	// A full page suggests that there are more records to fetch.
+	n, lastSysID, err := res.page()
+	if err != nil {
+		return resp, err
+	}
+	limit, _ := strconv.Atoi(opts.Limit)
+	full := limit > 0 && n == limit
+	switch {
+	case keyset:
+		resp.NextOffset = 0
+		if full {
+			resp.NextSysID = lastSysID
+		}
+	case resp.NextOffset == 0 && full && (resp.TotalCount == 0 || opts.Offset+n < resp.TotalCount):
+		resp.NextOffset = opts.Offset + n
+	}
+
+	return resp, nil
+}
+
+// keysetQuery returns the encoded query encoded, restricted to the records
+// whose sys_id sorts after afterSysID if it is set, and ordered by sys_id. The
+// sys_id condition is ANDed to every ^NQ group of the query, and ORDERBYsys_id
+// comes before any ordering of the query, so that it takes precedence.
+//
+// The query is rewritten as text rather than parsed, so that it may use any
+// operator ServiceNow supports, such as ON, DYNAMIC or INSTANCEOF.
+func keysetQuery(encoded, afterSysID string) (string, error) {
+	var cond string
+	if afterSysID != "" {
+		if err := validateQueryValue("sys_id", afterSysID); err != nil {
+			return "", err
+		}
+		cond = "sys_id" + string(Gt) + afterSysID
+	}
+
+	var groups, orderBy []string
+	for _, group := range strings.Split(encoded, string(NQ)) {
+		var terms []string
+		for _, term := range strings.Split(group, "^") {
+			switch {
+			case term == "" || term == "EQ":
+			case strings.HasPrefix(term, string(ORDERBY)):
+				orderBy = append(orderBy, term)
+			default:
+				terms = append(terms, term)
+			}
+		}
+		if len(terms) == 0 {
+			continue
+		}
+		if cond != "" {
+			terms = append(terms, cond)
+		}
+		groups = append(groups, strings.Join(terms, "^"))
+	}
+	if len(groups) == 0 && cond != "" {
+		groups = append(groups, cond)
+	}
+
+	clauses := append([]string{string(ORDERBY) + "sys_id"}, orderBy...)
+	if len(groups) == 0 {
+		return strings.Join(clauses, "^"), nil
+	}
+	return strings.Join(groups, string(NQ)) + "^" + strings.Join(clauses, "^"), nil
+}
+
+// getRecords fetches the records of table that match the query in opts.
+func (c *Client) getRecords(ctx context.Context, table string, opts GetOptions, v interface{}) (*Response, error) {
+	fields, err := selectFields(opts.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.tableURL(table, "")
+	if c.backend == BackendTable {
+		u, err = addTableOptions(u, tableOptions{
+			Query:        opts.SysparmQuery,
+			Limit:        "1",
+			DisplayValue: opts.DisplayValue,
+			Fields:       fields,
+			View:         opts.View,
+		})
+	} else {
+		u, err = addOptions(u, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRecords(ctx, "GET", u, nil, v)
+}
+
+// getRecord fetches the record sysID of table.
+func (c *Client) getRecord(ctx context.Context, table, sysID string, opts GetOptions, v interface{}) (*Response, error) {
+	fields, err := selectFields(opts.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.tableURL(table, sysID)
+	if c.backend == BackendTable {
+		u, err = addTableOptions(u, tableOptions{
+			DisplayValue: opts.DisplayValue,
+			Fields:       fields,
+			View:         opts.View,
+		})
+	} else {
+		opts.internalFields.SysparmAction = SysparmActionGet
+		opts.internalFields.SysparmSysID = &sysID
+		u, err = addOptions(u, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRecords(ctx, "GET", u, nil, v)
+}
+
+// createRecord inserts body into table.
+func (c *Client) createRecord(ctx context.Context, table string, body interface{}, opts CreateOptions, v interface{}) (*Response, error) {
+	u := c.tableURL(table, "")
+	var err error
+	if c.backend == BackendTable {
+		u, err = addTableOptions(u, tableOptions{DisplayValue: opts.DisplayValue})
+	} else {
+		opts.internalFields.SysparmAction = SysparmActionInsert
+		u, err = addOptions(u, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRecords(ctx, "POST", u, body, v)
+}
+
+// createRecords inserts body, a list of records, into table with a single
+// JSONv2 insertMultiple request.
+func (c *Client) createRecords(ctx context.Context, table string, body interface{}, opts CreateManyOptions, v interface{}) (*Response, error) {
+	opts.internalFields.SysparmAction = SysparmActionInsertMultiple
+	u, err := addOptions(c.tableURL(table, ""), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRecords(ctx, "POST", u, body, v)
+}
+
+// updateRecords updates the records of table that match the query in opts
+// with body. The Table API only updates records by sys_id, so the first
+// matching record is looked up before it is updated; if there is none, the
+// returned error wraps ErrRecordNotFound.
+func (c *Client) updateRecords(ctx context.Context, table string, body interface{}, opts UpdateOptions, v interface{}) (*Response, error) {
+	if c.backend != BackendTable {
+		opts.internalFields.SysparmAction = SysparmActionUpdate
+		u, err := addOptions(c.tableURL(table, ""), opts)
+		if err != nil {
+			return nil, err
+		}
+		return c.doRecords(ctx, "POST", u, body, v)
+	}
+
+	sysID, resp, err := c.lookupSysID(ctx, table, opts.SysparmQuery)
+	if err != nil {
+		return resp, err
+	}
+	if sysID == "" {
+		return resp, fmt.Errorf("%s %s: %w", table, opts.SysparmQuery, ErrRecordNotFound)
+	}
+
+	return c.updateRecord(ctx, table, sysID, body, opts, v)
+}
+
+// updateRecord updates the record sysID of table with body.
+func (c *Client) updateRecord(ctx context.Context, table, sysID string, body interface{}, opts UpdateOptions, v interface{}) (*Response, error) {
+	if c.backend != BackendTable {
+		q, err := fieldQuery("sys_id", sysID)
+		if err != nil {
+			return nil, err
+		}
+		opts.internalFields.SysparmQuery = q
+		return c.updateRecords(ctx, table, body, opts, v)
+	}
+
+	u, err := addTableOptions(c.tableURL(table, sysID), tableOptions{DisplayValue: opts.DisplayValue})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRecords(ctx, "PATCH", u, body, v)
+}
+
+// lookupSysID returns the sys_id of the first record of table that matches
+// the encoded query q, or an empty string if there is none.
+func (c *Client) lookupSysID(ctx context.Context, table, q string) (string, *Response, error) {
+	keys, resp, err := c.recordKeys(ctx, table, q, "1")
+	if err != nil || len(keys) == 0 {
+		return "", resp, err
+	}
+
+	return keys[0], resp, nil
+}
+
+// recordKeys returns the sys_ids of the records of table that match the
+// encoded query q, up to limit records if limit is not empty. Without a limit,
+// the sys_ids are fetched defaultPageSize at a time in sys_id order, since
+// both APIs cap the number of records a single request returns.
+func (c *Client) recordKeys(ctx context.Context, table, q, limit string) ([]string, *Response, error) {
+	if limit != "" {
+		return c.recordKeysPage(ctx, table, q, limit)
+	}
+
+	var (
+		keys  []string
+		resp  *Response
+		after string
+	)
+	for {
+		pq, err := keysetQuery(q, after)
+		if err != nil {
+			return nil, resp, err
+		}
+		var page []string
+		page, resp, err = c.recordKeysPage(ctx, table, pq, strconv.Itoa(defaultPageSize))
+		if err != nil {
+			return nil, resp, err
+		}
+		keys = append(keys, page...)
+		if len(page) < defaultPageSize {
+			return keys, resp, nil
+		}
+		after = page[len(page)-1]
+	}
+}
+
+// recordKeysPage returns the sys_ids of up to limit records of table that
+// match the encoded query q.
+func (c *Client) recordKeysPage(ctx context.Context, table, q, limit string) ([]string, *Response, error) {
+	if c.backend != BackendTable {
+		u, err := addOptions(c.tableURL(table, ""), ListOptions{
+			Limit: limit,
+			internalFields: internalFields{
+				SysparmAction: SysparmActionGetKeys,
+				SysparmQuery:  q,
+			},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var keys []string
+		resp, err := c.doRecords(ctx, "GET", u, nil, &keys)
+		return keys, resp, err
+	}
+
+	u, err := addTableOptions(c.tableURL(table, ""), tableOptions{
+		Query:  q,
+		Limit:  limit,
+		Fields: "sys_id",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []struct {
+		SysID string `json:"sys_id"`
+	}
+	resp, err := c.doRecords(ctx, "GET", u, nil, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	keys := make([]string, len(records))
+	for i, r := range records {
+		keys[i] = r.SysID
+	}
+	return keys, resp, nil
+}
+
+// deleteRecord deletes the record sysID of table.
+func (c *Client) deleteRecord(ctx context.Context, table, sysID string) (*Response, error) {
+	method, u := "DELETE", c.tableURL(table, sysID)
+	if c.backend != BackendTable {
+		var err error
+		method = "POST"
+		u, err = addOptions(u, DeleteOptions{
+			internalFields: internalFields{
+				SysparmAction: SysparmActionDelete,
+				SysparmSysID:  &sysID,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := c.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+// deleteRecords deletes the records of table whose sys_ids are keys, and
+// returns how many were deleted. JSONv2 deletes them defaultChunkSize at a
+// time, with a deleteMultiple request selecting their sys_ids, while the Table
+// API deletes them one by one.
+func (c *Client) deleteRecords(ctx context.Context, table string, keys []string) (int, *Response, error) {
+	var resp *Response
+	if c.backend != BackendTable {
+		for start := 0; start < len(keys); start += defaultChunkSize {
+			end := start + defaultChunkSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+
+			values := make([]interface{}, 0, end-start)
+			for _, key := range keys[start:end] {
+				values = append(values, key)
+			}
+			q, err := NewQuery().In("sys_id", values...).Encode()
+			if err != nil {
+				return start, resp, err
+			}
+			u, err := addOptions(c.tableURL(table, ""), DeleteOptions{
+				internalFields: internalFields{
+					SysparmAction: SysparmActionDeleteMultiple,
+					SysparmQuery:  q,
+				},
+			})
+			if err != nil {
+				return start, resp, err
+			}
+
+			req, err := c.NewRequest("POST", u, nil)
+			if err != nil {
+				return start, resp, err
+			}
+			if resp, err = c.Do(ctx, req, nil); err != nil {
+				return start, resp, err
+			}
+		}
+		return len(keys), resp, nil
+	}
+
+	for i, key := range keys {
+		var err error
+		resp, err = c.deleteRecord(ctx, table, key)
+		if err != nil {
+			return i, resp, err
+		}
+	}
+	return len(keys), resp, nil
+}
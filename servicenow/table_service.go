@@ -0,0 +1,594 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	defaultChunkSize = 100
+	defaultPageSize  = 1000
+)
+
+// TableService handles communication with the records of a single ServiceNow
+// table. T is the type records are decoded into and encoded from; it is
+// typically a struct with json tags named after the table's columns.
+//
+// Get, Update, UpdateFields and Delete identify records by their key field,
+// which is number unless set with WithKeyField. TableService works with any
+// table, including custom u_ tables:
+//
+//	type Team struct {
+//		SysID *string `json:"sys_id,omitempty"`
+//		Code  *string `json:"u_code,omitempty"`
+//		Name  *string `json:"u_name,omitempty"`
+//	}
+//
+//	teams := servicenow.NewTableService[Team](client, "u_team").WithKeyField("u_code")
+//	team, _, err := teams.Get(ctx, "sre", servicenow.GetOptions{})
+type TableService[T any] struct {
+	client *Client
+	table  string
+	key    string
+}
+
+// NewTableService returns a TableService for the records of table. Its key
+// field is number, unless T is a struct without a number field, in which case
+// it has no key field until one is set with WithKeyField.
+func NewTableService[T any](client *Client, table string) *TableService[T] {
+	key := "number"
+	if t := reflect.TypeOf((*T)(nil)).Elem(); t.Kind() == reflect.Struct && !fieldNames(t)[key] {
+		key = ""
+	}
+	return &TableService[T]{client: client, table: table, key: key}
+}
+
+// WithKeyField returns a copy of s that identifies records by field in Get,
+// Update, UpdateFields and Delete, such as a unique u_code field of a custom
+// table.
+func (s *TableService[T]) WithKeyField(field string) *TableService[T] {
+	c := *s
+	c.key = field
+	return &c
+}
+
+// KeyField returns the field Get, Update, UpdateFields and Delete identify
+// records by, or "" if there is none.
+func (s *TableService[T]) KeyField() string {
+	return s.key
+}
+
+// Table returns the name of the table the service talks to.
+func (s *TableService[T]) Table() string {
+	return s.table
+}
+
+// List records.
+func (s *TableService[T]) List(ctx context.Context, opts ListOptions) ([]*T, *Response, error) {
+	q, err := opts.encodedQuery()
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.internalFields.SysparmQuery = q
+
+	var records []*T
+	resp, err := s.client.listRecords(ctx, s.table, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records, resp, nil
+}
+
+// ListAll lists all records that match opts, fetching as many pages as
+// needed. See Iter for how pages are requested. The returned Response is the
+// one of the last page.
+func (s *TableService[T]) ListAll(ctx context.Context, opts ListOptions) ([]*T, *Response, error) {
+	var records []*T
+	it := s.Iter(ctx, opts)
+	for it.Next() {
+		records = append(records, it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, it.Response(), err
+	}
+	return records, it.Response(), nil
+}
+
+// Iter returns an Iterator over all records that match opts. Records are
+// fetched lazily, opts.Limit records at a time (1000 if unset), using keyset
+// pagination unless opts.Offset is set. Keyset pagination orders records by
+// sys_id, which takes precedence over any ordering in the query.
+func (s *TableService[T]) Iter(ctx context.Context, opts ListOptions) *Iterator[T] {
+	if opts.Limit == "" {
+		opts.Limit = strconv.Itoa(defaultPageSize)
+	}
+	if opts.Offset == 0 {
+		opts.Keyset = true
+	}
+	return &Iterator[T]{ctx: ctx, service: s, opts: opts}
+}
+
+// Iterator iterates over the records of a table across pages. Its zero value
+// is not usable; create one with TableService.Iter.
+//
+//	it := client.Incidents.Iter(ctx, opts)
+//	for it.Next() {
+//		inc := it.Record()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	ctx     context.Context
+	service *TableService[T]
+	opts    ListOptions
+
+	page []*T
+	next *T
+	resp *Response
+	err  error
+	done bool
+}
+
+// Next advances the iterator to the next record, fetching the next page if
+// needed. It returns false when there are no more records or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.page, it.resp, it.err = it.service.List(it.ctx, it.opts)
+		if it.err != nil {
+			return false
+		}
+		switch {
+		case it.resp.NextSysID != "":
+			it.opts.AfterSysID = it.resp.NextSysID
+		case it.resp.NextOffset != 0:
+			it.opts.Offset = it.resp.NextOffset
+		default:
+			it.done = true
+		}
+	}
+	it.next, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Record returns the current record.
+func (it *Iterator[T]) Record() *T {
+	return it.next
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the Response of the last page fetched.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// Get a single record by number, or by the key field set with WithKeyField.
+// If no record has that number, the returned error wraps ErrRecordNotFound.
+func (s *TableService[T]) Get(ctx context.Context, number string, opts GetOptions) (*T, *Response, error) {
+	records, resp, err := s.getRecords(ctx, ByNumber(number), opts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	record, err := s.first(records, ByNumber(number))
+	return record, resp, err
+}
+
+// GetBySysID gets a single record by sys_id. If there is no such record,
+// IsNotFound reports true for the returned error.
+func (s *TableService[T]) GetBySysID(ctx context.Context, sysID string, opts GetOptions) (*T, *Response, error) {
+	if sysID == "" {
+		return nil, nil, fmt.Errorf("%s sys_id cannot be empty", s.table)
+	}
+
+	records, resp, err := s.getRecords(ctx, BySysID(sysID), opts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	record, err := s.first(records, BySysID(sysID))
+	return record, resp, err
+}
+
+// GetByRef gets a single record by number or sys_id, whichever ref holds.
+func (s *TableService[T]) GetByRef(ctx context.Context, ref RecordRef, opts GetOptions) (*T, *Response, error) {
+	if ref.SysID != "" {
+		return s.GetBySysID(ctx, ref.SysID, opts)
+	}
+	return s.Get(ctx, ref.Number, opts)
+}
+
+// getRecords returns the records ref refers to, of which there is at most
+// one, so that callers can tell a missing record from an empty one.
+func (s *TableService[T]) getRecords(ctx context.Context, ref RecordRef, opts GetOptions) ([]*T, *Response, error) {
+	var records []*T // Though servicenow docs say they return a record, we get records (array).
+	if ref.SysID != "" {
+		resp, err := s.client.getRecord(ctx, s.table, ref.SysID, opts, &records)
+		return records, resp, err
+	}
+
+	q, err := s.keyQuery(ref.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.internalFields.SysparmQuery = q
+	resp, err := s.client.getRecords(ctx, s.table, opts, &records)
+	return records, resp, err
+}
+
+// Create a new record.
+func (s *TableService[T]) Create(ctx context.Context, record *T, opts CreateOptions) (*T, *Response, error) {
+	body, err := encodeRecord(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*T
+	resp, err := s.client.createRecord(ctx, s.table, body, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(records) == 0 {
+		return nil, resp, fmt.Errorf("%s insert returned no record", s.table)
+	}
+
+	return records[0], resp, nil
+}
+
+// RecordRef identifies a record by either its number, which is matched
+// against the key field of the TableService, or its sys_id. If both are set,
+// the sys_id is used.
+type RecordRef struct {
+	Number string
+	SysID  string
+}
+
+// ByNumber returns a RecordRef to the record with the given number.
+func ByNumber(number string) RecordRef {
+	return RecordRef{Number: number}
+}
+
+// BySysID returns a RecordRef to the record with the given sys_id.
+func BySysID(sysID string) RecordRef {
+	return RecordRef{SysID: sysID}
+}
+
+func (r RecordRef) String() string {
+	if r.SysID != "" {
+		return "sys_id=" + r.SysID
+	}
+	return "number=" + r.Number
+}
+
+// CreateResult is the outcome of inserting a single record with CreateMany.
+type CreateResult[T any] struct {
+	Index  int   // Index of the record in the slice passed to CreateMany
+	Record *T    // Record as returned by the instance, nil if Err is set
+	Err    error // Error that prevented the record from being inserted
+}
+
+// RecordError reports the failure of a single record of a batch operation.
+type RecordError struct {
+	Index int         // Index of the record in the batch
+	Err   ErrorDetail // Error returned by ServiceNow
+}
+
+func (e *RecordError) Error() string {
+	msg := e.Err.Message
+	if e.Err.Detail != "" {
+		msg += ": " + e.Err.Detail
+	}
+	return fmt.Sprintf("record %d: %v", e.Index, msg)
+}
+
+// CreateMany inserts records in chunks of opts.ChunkSize and returns one
+// result per record, in the order of records. With the JSONv2 backend each
+// chunk is inserted with a single insertMultiple request; the Table API has
+// no bulk insert, so records are inserted one by one.
+//
+// A failed request fails every record of its chunk, but does not stop the
+// remaining chunks from being inserted. The returned error is the first error
+// recorded in the results, if any, and the returned Response is the one of the
+// last request. If records holds a nil record, nothing is inserted and a
+// *RecordError for its index is returned.
+func (s *TableService[T]) CreateMany(ctx context.Context, records []*T, opts CreateManyOptions) ([]*CreateResult[T], *Response, error) {
+	for i, record := range records {
+		if record == nil {
+			return nil, nil, &RecordError{Index: i, Err: ErrorDetail{Message: "record is nil"}}
+		}
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+
+	results := make([]*CreateResult[T], len(records))
+	var resp *Response
+	for start := 0; start < len(records); start += size {
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+
+		if s.client.backend == BackendTable {
+			for i := start; i < end; i++ {
+				var record *T
+				var err error
+				record, resp, err = s.Create(ctx, records[i], CreateOptions{DisplayValue: opts.DisplayValue})
+				results[i] = &CreateResult[T]{Index: i, Record: record, Err: err}
+			}
+			continue
+		}
+
+		var raw []json.RawMessage
+		body := struct {
+			Records []json.RawMessage `json:"records"`
+		}{make([]json.RawMessage, end-start)}
+		var err error
+		for i := start; i < end && err == nil; i++ {
+			body.Records[i-start], err = encodeRecord(records[i])
+		}
+		if err == nil {
+			resp, err = s.client.createRecords(ctx, s.table, body, opts, &raw)
+		}
+		for i := start; i < end; i++ {
+			results[i] = &CreateResult[T]{Index: i}
+			switch {
+			case err != nil:
+				results[i].Err = err
+			case i-start >= len(raw):
+				results[i].Err = &RecordError{Index: i, Err: ErrorDetail{Message: "no result returned for record"}}
+			default:
+				results[i].Record, results[i].Err = decodeCreateResult[T](raw[i-start], i)
+			}
+		}
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			return results, resp, r.Err
+		}
+	}
+	return results, resp, nil
+}
+
+// decodeCreateResult decodes a record returned by a JSONv2 insertMultiple
+// request, which reports the outcome of each record in its __status and
+// __error fields.
+func decodeCreateResult[T any](data json.RawMessage, index int) (*T, error) {
+	var status struct {
+		Status string       `json:"__status"`
+		Error  *ErrorDetail `json:"__error"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	if status.Status == "failure" || status.Error != nil {
+		recordErr := &RecordError{Index: index}
+		if status.Error != nil {
+			recordErr.Err = *status.Error
+		}
+		if recordErr.Err.Message == "" {
+			recordErr.Err.Message = "insert failed"
+		}
+		return nil, recordErr
+	}
+
+	record := new(T)
+	if err := UnmarshalRecord(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Update an existing record by number, or by the key field set with
+// WithKeyField. If no record has that number, the returned error wraps
+// ErrRecordNotFound.
+func (s *TableService[T]) Update(ctx context.Context, number string, record *T, opts UpdateOptions) (*T, *Response, error) {
+	q, err := s.keyQuery(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.internalFields.SysparmQuery = q
+	body, err := encodeRecord(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*T
+	resp, err := s.client.updateRecords(ctx, s.table, body, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	updated, err := s.first(records, ByNumber(number))
+	return updated, resp, err
+}
+
+// UpdateBySysID updates an existing record by sys_id. If there is no such
+// record, IsNotFound reports true for the returned error.
+func (s *TableService[T]) UpdateBySysID(ctx context.Context, sysID string, record *T, opts UpdateOptions) (*T, *Response, error) {
+	if sysID == "" {
+		return nil, nil, fmt.Errorf("%s sys_id cannot be empty", s.table)
+	}
+	body, err := encodeRecord(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*T
+	resp, err := s.client.updateRecord(ctx, s.table, sysID, body, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	updated, err := s.first(records, BySysID(sysID))
+	return updated, resp, err
+}
+
+// UpdateFields updates only the given fields of an existing record by
+// number, or by the key field set with WithKeyField, leaving the other fields
+// as they are. fields maps field names to their new values, such as the Patch
+// of the Changes returned by Diff. See Update for records that do not exist.
+func (s *TableService[T]) UpdateFields(ctx context.Context, number string, fields map[string]interface{}, opts UpdateOptions) (*T, *Response, error) {
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("%s fields cannot be empty", s.table)
+	}
+	q, err := s.keyQuery(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.internalFields.SysparmQuery = q
+
+	var records []*T
+	resp, err := s.client.updateRecords(ctx, s.table, fields, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	updated, err := s.first(records, ByNumber(number))
+	return updated, resp, err
+}
+
+// UpdateFieldsBySysID updates only the given fields of an existing record by
+// sys_id. See UpdateFields.
+func (s *TableService[T]) UpdateFieldsBySysID(ctx context.Context, sysID string, fields map[string]interface{}, opts UpdateOptions) (*T, *Response, error) {
+	if sysID == "" {
+		return nil, nil, fmt.Errorf("%s sys_id cannot be empty", s.table)
+	}
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("%s fields cannot be empty", s.table)
+	}
+
+	var records []*T
+	resp, err := s.client.updateRecord(ctx, s.table, sysID, fields, opts, &records)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	updated, err := s.first(records, BySysID(sysID))
+	return updated, resp, err
+}
+
+// UpdateByRef updates an existing record by number or sys_id, whichever ref
+// holds.
+func (s *TableService[T]) UpdateByRef(ctx context.Context, ref RecordRef, record *T, opts UpdateOptions) (*T, *Response, error) {
+	if ref.SysID != "" {
+		return s.UpdateBySysID(ctx, ref.SysID, record, opts)
+	}
+	return s.Update(ctx, ref.Number, record, opts)
+}
+
+// Delete a single record by number, or by the key field set with
+// WithKeyField. If no record has that number, the returned error wraps
+// ErrRecordNotFound.
+func (s *TableService[T]) Delete(ctx context.Context, number string) (*Response, error) {
+	q, err := s.keyQuery(number)
+	if err != nil {
+		return nil, err
+	}
+	sysID, resp, err := s.client.lookupSysID(ctx, s.table, q)
+	if err != nil {
+		return resp, err
+	}
+	if sysID == "" {
+		return resp, fmt.Errorf("%s %s: %w", s.table, number, ErrRecordNotFound)
+	}
+
+	return s.client.deleteRecord(ctx, s.table, sysID)
+}
+
+// DeleteBySysID deletes a single record by sys_id.
+func (s *TableService[T]) DeleteBySysID(ctx context.Context, sysID string) (*Response, error) {
+	if sysID == "" {
+		return nil, fmt.Errorf("%s sys_id cannot be empty", s.table)
+	}
+
+	return s.client.deleteRecord(ctx, s.table, sysID)
+}
+
+// DeleteByRef deletes a single record by number or sys_id, whichever ref
+// holds.
+func (s *TableService[T]) DeleteByRef(ctx context.Context, ref RecordRef) (*Response, error) {
+	if ref.SysID != "" {
+		return s.DeleteBySysID(ctx, ref.SysID)
+	}
+	return s.Delete(ctx, ref.Number)
+}
+
+// DeleteByQuery deletes the records that match the encoded query and returns
+// how many were deleted. The query must have at least one condition in each
+// of its ^NQ groups. With opts.DryRun, the matching records are only counted.
+//
+// The sys_ids of the matching records are fetched first, and only those
+// records are deleted, so that records inserted in the meantime are left
+// alone and the count is exact.
+//
+// ServiceNow ignores conditions on fields that do not exist, so a mistyped
+// query may match far more records than intended. Use a dry run or
+// opts.MaxRecords to guard against that.
+func (s *TableService[T]) DeleteByQuery(ctx context.Context, query string, opts DeleteOptions) (int, *Response, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(q.groups) == 0 {
+		return 0, nil, fmt.Errorf("%s query %q has no conditions", s.table, query)
+	}
+	if q.nq {
+		// A trailing ^NQ starts a group without conditions.
+		return 0, nil, fmt.Errorf("%s query %q has a group without conditions", s.table, query)
+	}
+
+	keys, resp, err := s.client.recordKeys(ctx, s.table, query, "")
+	if err != nil {
+		return 0, resp, err
+	}
+	if opts.DryRun || len(keys) == 0 {
+		return len(keys), resp, nil
+	}
+	if opts.MaxRecords > 0 && len(keys) > opts.MaxRecords {
+		return 0, resp, fmt.Errorf("%s query matches %d records, more than the maximum of %d", s.table, len(keys), opts.MaxRecords)
+	}
+
+	return s.client.deleteRecords(ctx, s.table, keys)
+}
+
+// keyQuery returns the encoded query that selects the record whose key field
+// equals key.
+func (s *TableService[T]) keyQuery(key string) (string, error) {
+	if s.key == "" {
+		return "", fmt.Errorf("%s has no number field; set its key field with WithKeyField", s.table)
+	}
+	if key == "" {
+		return "", fmt.Errorf("%s %s cannot be empty", s.table, s.key)
+	}
+	return fieldQuery(s.key, key)
+}
+
+// encodeRecord encodes record with MarshalRecord, for use as a request body.
+func encodeRecord(record interface{}) (json.RawMessage, error) {
+	return MarshalRecord(record)
+}
+
+// first returns the first of records, which hold the record ref refers to, or
+// an error wrapping ErrRecordNotFound if there is none.
+func (s *TableService[T]) first(records []*T, ref RecordRef) (*T, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s %s: %w", s.table, ref, ErrRecordNotFound)
+	}
+	return records[0], nil
+}
@@ -0,0 +1,454 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type team struct {
+	SysID *string `json:"sys_id,omitempty"`
+	Code  *string `json:"u_code,omitempty"`
+}
+
+func TestTableService_keyField(t *testing.T) {
+	client, mux := setup(t)
+	ctx := context.Background()
+
+	if got := client.Incidents.KeyField(); got != "number" {
+		t.Errorf("Incidents.KeyField = %q, want number", got)
+	}
+	if got := NewTableService[map[string]interface{}](client, "u_team").KeyField(); got != "number" {
+		t.Errorf("KeyField of a map service = %q, want number", got)
+	}
+
+	mux.HandleFunc("/u_team.do", func(w http.ResponseWriter, r *http.Request) {
+		testQuery(t, r, "u_code=sre")
+		writeJSON(t, w, records(map[string]interface{}{"sys_id": "s1", "u_code": "sre"}))
+	})
+
+	// Number-based calls are refused on a table without a number field.
+	teams := NewTableService[team](client, "u_team")
+	if got := teams.KeyField(); got != "" {
+		t.Errorf("KeyField = %q, want none", got)
+	}
+	if _, _, err := teams.Get(ctx, "sre", GetOptions{}); err == nil {
+		t.Errorf("Get without a key field returned no error")
+	}
+	if _, _, err := teams.Update(ctx, "sre", &team{}, UpdateOptions{}); err == nil {
+		t.Errorf("Update without a key field returned no error")
+	}
+	if _, _, err := teams.UpdateFields(ctx, "sre", map[string]interface{}{"u_name": "x"}, UpdateOptions{}); err == nil {
+		t.Errorf("UpdateFields without a key field returned no error")
+	}
+	if _, err := teams.Delete(ctx, "sre"); err == nil {
+		t.Errorf("Delete without a key field returned no error")
+	}
+
+	keyed := teams.WithKeyField("u_code")
+	if teams.KeyField() != "" {
+		t.Errorf("WithKeyField changed the key field of the original service")
+	}
+	got, _, err := keyed.Get(ctx, "sre", GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.SysID == nil || *got.SysID != "s1" {
+		t.Errorf("Get returned %+v, want record s1", got)
+	}
+	if _, _, err := keyed.Get(ctx, "", GetOptions{}); err == nil {
+		t.Errorf("Get with an empty key returned no error")
+	}
+}
+
+func TestTableService_List_sysparmQuery(t *testing.T) {
+	client, mux := setup(t)
+	var got []string
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("sysparm_query"))
+		writeJSON(t, w, records())
+	})
+	ctx := context.Background()
+
+	opts := ListOptions{}
+	opts.SysparmQuery = "active=true"
+	if _, _, err := client.Incidents.List(ctx, opts); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if want := []string{"active=true"}; !equalStrings(got, want) {
+		t.Errorf("List sent sysparm_query %q, want %q", got, want)
+	}
+
+	opts.Query = NewQuery().Eq("priority", 1)
+	if _, _, err := client.Incidents.List(ctx, opts); err == nil {
+		t.Errorf("List with both SysparmQuery and Query returned no error")
+	}
+	opts.Query = nil
+	opts.QueryOpts = []QueryOpts{{Key: "priority", Op: Eq, Val: "1"}}
+	if _, _, err := client.Incidents.List(ctx, opts); err == nil {
+		t.Errorf("List with both SysparmQuery and QueryOpts returned no error")
+	}
+	if len(got) != 1 {
+		t.Errorf("List sent %d requests, want 1", len(got))
+	}
+}
+
+func TestTableService_Update_notFound(t *testing.T) {
+	client, mux := setup(t, WithBackend(BackendTable))
+	mux.HandleFunc("/api/now/table/incident", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "number=INC1")
+		writeJSON(t, w, result([]map[string]interface{}{}))
+	})
+	ctx := context.Background()
+
+	_, _, err := client.Incidents.Update(ctx, "INC1", &Incident{Description: NewField("x")}, UpdateOptions{})
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Update returned error %v, want ErrRecordNotFound", err)
+	}
+	_, _, err = client.Incidents.UpdateFields(ctx, "INC1", map[string]interface{}{"description": "x"}, UpdateOptions{})
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("UpdateFields returned error %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestTableService_DeleteByQuery(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		// More records match than the instance returns without a limit.
+		f := &fakeTable{t: t, backend: backend, maxRecords: 1000}
+		for i := 0; i < 2500; i++ {
+			a := "1"
+			if i%10 == 0 {
+				a = "0"
+			}
+			f.records = append(f.records, map[string]interface{}{"sys_id": fmt.Sprintf("s%04d", i), "a": a})
+		}
+		var deleteQueries []string
+		mux.Handle("/incident.do", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("sysparm_action") == SysparmActionDeleteMultiple {
+				deleteQueries = append(deleteQueries, r.URL.Query().Get("sysparm_query"))
+			}
+			f.ServeHTTP(w, r)
+		}))
+		mux.Handle("/api/now/table/incident", f)
+		mux.Handle("/api/now/table/incident/", f)
+		ctx := context.Background()
+
+		n, _, err := client.Incidents.DeleteByQuery(ctx, "a=1", DeleteOptions{DryRun: true})
+		if err != nil || n != 2250 {
+			t.Errorf("backend %v: DeleteByQuery dry run = %d, %v, want 2250", backend, n, err)
+		}
+		if _, _, err := client.Incidents.DeleteByQuery(ctx, "a=0", DeleteOptions{MaxRecords: 100}); err == nil {
+			t.Errorf("backend %v: DeleteByQuery over MaxRecords returned no error", backend)
+		}
+		if len(f.records) != 2500 {
+			t.Fatalf("backend %v: %d records left after dry run and MaxRecords, want 2500", backend, len(f.records))
+		}
+
+		n, _, err = client.Incidents.DeleteByQuery(ctx, "a=0", DeleteOptions{})
+		if err != nil || n != 250 {
+			t.Errorf("backend %v: DeleteByQuery = %d, %v, want 250", backend, n, err)
+		}
+		if len(f.records) != 2250 {
+			t.Errorf("backend %v: %d records left, want 2250", backend, len(f.records))
+		}
+		for _, rec := range f.records {
+			if rec["a"] != "1" {
+				t.Errorf("backend %v: record %v was not deleted", backend, rec["sys_id"])
+				break
+			}
+		}
+		if backend == BackendJSONv2 {
+			if len(deleteQueries) != 3 {
+				t.Errorf("deleteMultiple requests = %d, want 3 chunks", len(deleteQueries))
+			}
+			for _, q := range deleteQueries {
+				if !strings.HasPrefix(q, "sys_idIN") {
+					t.Errorf("deleteMultiple sysparm_query = %q, want sys_idIN the counted keys", q)
+				}
+			}
+		}
+	}
+}
+
+func TestTableService_DeleteByQuery_invalid(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/", failHandler(t))
+	for _, q := range []string{"", "  ", "ORDERBYnumber", "active", "active=true^NQ", "^NQactive=true"} {
+		if _, _, err := client.Incidents.DeleteByQuery(context.Background(), q, DeleteOptions{}); err == nil {
+			t.Errorf("DeleteByQuery(%q) returned no error", q)
+		}
+	}
+}
+
+func TestTableService_CreateMany(t *testing.T) {
+	client, mux := setup(t)
+	var chunks [][]string
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.URL.Query().Get("sysparm_action"); got != SysparmActionInsertMultiple {
+			t.Errorf("sysparm_action = %q, want %q", got, SysparmActionInsertMultiple)
+		}
+		var body struct {
+			Records []map[string]interface{} `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Decode returned error: %v", err)
+		}
+		var chunk []string
+		var recs []map[string]interface{}
+		for _, rec := range body.Records {
+			desc := rec["short_description"].(string)
+			chunk = append(chunk, desc)
+			switch desc {
+			case "bad":
+				recs = append(recs, map[string]interface{}{
+					"__status": "failure",
+					"__error":  map[string]string{"message": "Invalid", "detail": "bad record"},
+				})
+			default:
+				recs = append(recs, map[string]interface{}{"sys_id": "s-" + desc, "short_description": desc})
+			}
+		}
+		chunks = append(chunks, chunk)
+		for _, desc := range chunk {
+			if desc == "down" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		writeJSON(t, w, records(recs...))
+	})
+
+	descs := []string{"a", "b", "bad", "c", "down", "d", "e"}
+	var incs []*Incident
+	for _, d := range descs {
+		incs = append(incs, &Incident{ShortDescription: NewField(d)})
+	}
+	results, _, err := client.Incidents.CreateMany(context.Background(), incs, CreateManyOptions{ChunkSize: 3})
+
+	wantChunks := [][]string{{"a", "b", "bad"}, {"c", "down", "d"}, {"e"}}
+	if fmt.Sprint(chunks) != fmt.Sprint(wantChunks) {
+		t.Errorf("chunks = %v, want %v", chunks, wantChunks)
+	}
+	var recordErr *RecordError
+	if !errors.As(err, &recordErr) || recordErr.Index != 2 {
+		t.Errorf("CreateMany returned error %v, want the RecordError of record 2", err)
+	}
+	if len(results) != len(descs) {
+		t.Fatalf("CreateMany returned %d results, want %d", len(results), len(descs))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("results[%d].Index = %d", i, r.Index)
+		}
+		switch descs[i] {
+		case "bad":
+			var e *RecordError
+			if !errors.As(r.Err, &e) || e.Index != i || e.Err.Detail != "bad record" {
+				t.Errorf("results[%d].Err = %v, want the RecordError of record %d", i, r.Err, i)
+			}
+		case "c", "down", "d":
+			var e *ErrorResponse
+			if !errors.As(r.Err, &e) || r.Record != nil {
+				t.Errorf("results[%d] = %+v, want the ErrorResponse of its chunk", i, r)
+			}
+		default:
+			if r.Err != nil || r.Record.GetSysID() != "s-"+descs[i] {
+				t.Errorf("results[%d] = %+v, want record s-%s", i, r, descs[i])
+			}
+		}
+	}
+}
+
+func TestTableService_CreateMany_tableAPI(t *testing.T) {
+	client, mux := setup(t, WithBackend(BackendTable))
+	var got []string
+	mux.HandleFunc("/api/now/table/incident", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var rec map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			t.Fatalf("Decode returned error: %v", err)
+		}
+		desc := rec["short_description"].(string)
+		got = append(got, desc)
+		writeJSON(t, w, result(map[string]interface{}{"sys_id": "s-" + desc}))
+	})
+
+	incs := []*Incident{
+		{ShortDescription: NewField("a")},
+		{ShortDescription: NewField("b")},
+		{ShortDescription: NewField("c")},
+	}
+	results, _, err := client.Incidents.CreateMany(context.Background(), incs, CreateManyOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("CreateMany returned error: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !equalStrings(got, want) {
+		t.Errorf("inserted %v, want %v one by one", got, want)
+	}
+	for i, r := range results {
+		if r.Index != i || r.Record.GetSysID() != "s-"+got[i] {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+}
+
+func TestTableService_CreateMany_nilRecord(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/", failHandler(t))
+
+	incs := []*Incident{{ShortDescription: NewField("a")}, nil}
+	_, _, err := client.Incidents.CreateMany(context.Background(), incs, CreateManyOptions{})
+	var e *RecordError
+	if !errors.As(err, &e) || e.Index != 1 {
+		t.Errorf("CreateMany returned error %v, want the RecordError of record 1", err)
+	}
+}
+
+// sysIDHandler serves the incident s1, numbered INC1, by sys_id and number on
+// either backend, and records the requests it receives.
+type sysIDHandler struct {
+	t        *testing.T
+	backend  Backend
+	requests []string
+}
+
+func (h *sysIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	h.requests = append(h.requests, r.Method+" "+r.URL.Path+" "+params.Get("sysparm_action"))
+
+	rec := map[string]interface{}{"sys_id": "s1", "number": "INC1", "short_description": "old"}
+	if r.Method == "POST" || r.Method == "PATCH" {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil {
+			for k, v := range body {
+				rec[k] = v
+			}
+		}
+	}
+
+	if h.backend == BackendTable {
+		if strings.HasSuffix(r.URL.Path, "/incident") {
+			if params.Get("sysparm_query") != "number=INC1" {
+				writeJSON(h.t, w, result([]map[string]interface{}{}))
+				return
+			}
+			writeJSON(h.t, w, result([]map[string]interface{}{rec}))
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/incident/s1") {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(h.t, w, map[string]interface{}{"error": map[string]string{"message": "No Record found"}, "status": "failure"})
+			return
+		}
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(h.t, w, result(rec))
+		return
+	}
+
+	found := params.Get("sysparm_sys_id") == "s1"
+	switch q := params.Get("sysparm_query"); {
+	case q == "sys_id=s1", q == "number=INC1":
+		found = true
+	case params.Get("sysparm_action") == SysparmActionGetKeys:
+		writeJSON(h.t, w, map[string]interface{}{"records": []string{}})
+		return
+	}
+	if !found || params.Get("sysparm_action") == SysparmActionDelete {
+		writeJSON(h.t, w, records())
+		return
+	}
+	writeJSON(h.t, w, records(rec))
+}
+
+func TestTableService_sysID(t *testing.T) {
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		client, mux := setup(t, WithBackend(backend))
+		h := &sysIDHandler{t: t, backend: backend}
+		mux.Handle("/incident.do", h)
+		mux.Handle("/api/now/table/incident", h)
+		mux.Handle("/api/now/table/incident/", h)
+		ctx := context.Background()
+
+		for _, ref := range []RecordRef{BySysID("s1"), ByNumber("INC1")} {
+			inc, _, err := client.Incidents.GetByRef(ctx, ref, GetOptions{})
+			if err != nil || inc.GetSysID() != "s1" {
+				t.Errorf("backend %v: GetByRef(%v) = %v, %v, want s1", backend, ref, inc, err)
+			}
+			inc, _, err = client.Incidents.UpdateByRef(ctx, ref, &Incident{ShortDescription: NewField("new")}, UpdateOptions{})
+			if err != nil || inc.GetShortDescription() != "new" {
+				t.Errorf("backend %v: UpdateByRef(%v) = %v, %v, want the updated record", backend, ref, inc, err)
+			}
+		}
+		inc, _, err := client.Incidents.UpdateFieldsBySysID(ctx, "s1", map[string]interface{}{"short_description": "new"}, UpdateOptions{})
+		if err != nil || inc.GetShortDescription() != "new" {
+			t.Errorf("backend %v: UpdateFieldsBySysID = %v, %v, want the updated record", backend, inc, err)
+		}
+
+		h.requests = nil
+		if _, err := client.Incidents.DeleteByRef(ctx, BySysID("s1")); err != nil {
+			t.Errorf("backend %v: DeleteByRef returned error: %v", backend, err)
+		}
+		want := "DELETE /api/now/table/incident/s1 "
+		if backend == BackendJSONv2 {
+			want = "POST /incident.do " + SysparmActionDelete
+		}
+		if len(h.requests) != 1 || h.requests[0] != want {
+			t.Errorf("backend %v: DeleteByRef sent %q, want %q", backend, h.requests, want)
+		}
+
+		// A missing record is reported as not found rather than as an empty record.
+		if _, _, err := client.Incidents.GetBySysID(ctx, "s2", GetOptions{}); !IsNotFound(err) {
+			t.Errorf("backend %v: GetBySysID of a missing record returned error %v, want not found", backend, err)
+		}
+		if _, _, err := client.Incidents.UpdateBySysID(ctx, "s2", &Incident{}, UpdateOptions{}); !IsNotFound(err) {
+			t.Errorf("backend %v: UpdateBySysID of a missing record returned error %v, want not found", backend, err)
+		}
+		if _, _, err := client.Incidents.UpdateFieldsBySysID(ctx, "s2", map[string]interface{}{"a": "b"}, UpdateOptions{}); !IsNotFound(err) {
+			t.Errorf("backend %v: UpdateFieldsBySysID of a missing record returned error %v, want not found", backend, err)
+		}
+		ref := ByNumber("INC2")
+		if _, _, err := client.Incidents.GetByRef(ctx, ref, GetOptions{}); !errors.Is(err, ErrRecordNotFound) {
+			t.Errorf("backend %v: GetByRef(%v) returned error %v, want ErrRecordNotFound", backend, ref, err)
+		}
+		if _, _, err := client.Incidents.UpdateByRef(ctx, ref, &Incident{}, UpdateOptions{}); !errors.Is(err, ErrRecordNotFound) {
+			t.Errorf("backend %v: UpdateByRef(%v) returned error %v, want ErrRecordNotFound", backend, ref, err)
+		}
+		if _, err := client.Incidents.DeleteByRef(ctx, ref); !errors.Is(err, ErrRecordNotFound) {
+			t.Errorf("backend %v: DeleteByRef(%v) returned error %v, want ErrRecordNotFound", backend, ref, err)
+		}
+		if backend == BackendJSONv2 {
+			if _, _, err := client.Incidents.GetBySysID(ctx, "s2", GetOptions{}); !errors.Is(err, ErrRecordNotFound) {
+				t.Errorf("GetBySysID of a missing record returned error %v, want ErrRecordNotFound", err)
+			}
+		}
+	}
+}
+
+func TestTableService_sysID_empty(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/", failHandler(t))
+	ctx := context.Background()
+
+	if _, _, err := client.Incidents.GetBySysID(ctx, "", GetOptions{}); err == nil {
+		t.Errorf("GetBySysID with an empty sys_id returned no error")
+	}
+	if _, _, err := client.Incidents.UpdateBySysID(ctx, "", &Incident{}, UpdateOptions{}); err == nil {
+		t.Errorf("UpdateBySysID with an empty sys_id returned no error")
+	}
+	if _, err := client.Incidents.DeleteBySysID(ctx, ""); err == nil {
+		t.Errorf("DeleteBySysID with an empty sys_id returned no error")
+	}
+	if _, _, err := client.Incidents.GetByRef(ctx, RecordRef{}, GetOptions{}); err == nil {
+		t.Errorf("GetByRef with an empty RecordRef returned no error")
+	}
+}
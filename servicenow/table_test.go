@@ -0,0 +1,283 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTable is a table of a fake instance, which evaluates the sysparm_query
+// of list, getKeys and deleteMultiple requests with ParseQuery and Match.
+type fakeTable struct {
+	t       *testing.T
+	backend Backend
+	records []map[string]interface{}
+
+	// maxRecords, if positive, caps the number of records returned by
+	// requests without a limit, as instances do.
+	maxRecords int
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (f *fakeTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	params := r.URL.Query()
+	if r.Method == "DELETE" {
+		f.delete(func(rec map[string]interface{}) bool {
+			return strings.HasSuffix(r.URL.Path, "/"+rec["sys_id"].(string))
+		})
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	q, err := ParseQuery(params.Get("sysparm_query"))
+	if err != nil {
+		f.t.Errorf("sysparm_query %q: %v", params.Get("sysparm_query"), err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	match := func(rec map[string]interface{}) bool {
+		ok, err := q.Match(rec)
+		if err != nil {
+			f.t.Errorf("Match returned error: %v", err)
+		}
+		return ok
+	}
+	if params.Get("sysparm_action") == SysparmActionDeleteMultiple {
+		f.delete(match)
+		writeJSON(f.t, w, records())
+		return
+	}
+
+	var matched []map[string]interface{}
+	for _, rec := range f.records {
+		if match(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	sortRecords(matched, q.orderBy)
+
+	limit := params.Get("sysparm_limit")
+	if f.backend != BackendTable {
+		limit = params.Get("sysparm_record_count")
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil {
+		n = f.maxRecords
+	}
+	if n > 0 && n < len(matched) {
+		matched = matched[:n]
+	}
+	if matched == nil {
+		matched = []map[string]interface{}{}
+	}
+
+	switch {
+	case f.backend == BackendTable:
+		writeJSON(f.t, w, result(matched))
+	case params.Get("sysparm_action") == SysparmActionGetKeys:
+		keys := make([]string, len(matched))
+		for i, rec := range matched {
+			keys[i] = rec["sys_id"].(string)
+		}
+		writeJSON(f.t, w, map[string]interface{}{"records": keys})
+	default:
+		writeJSON(f.t, w, records(matched...))
+	}
+}
+
+// delete deletes the records for which match returns true.
+func (f *fakeTable) delete(match func(rec map[string]interface{}) bool) {
+	var kept []map[string]interface{}
+	for _, rec := range f.records {
+		if !match(rec) {
+			kept = append(kept, rec)
+		}
+	}
+	f.records = kept
+}
+
+// sortRecords sorts records by the ORDERBY and ORDERBYDESC clauses of a
+// query, the first clause taking precedence.
+func sortRecords(records []map[string]interface{}, orderBy []string) {
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, o := range orderBy {
+			desc := strings.HasPrefix(o, string(ORDERBYDESC))
+			field := strings.TrimPrefix(strings.TrimPrefix(o, string(ORDERBYDESC)), string(ORDERBY))
+			a, b := fieldString(records[i][field]), fieldString(records[j][field])
+			if a == b {
+				continue
+			}
+			return (a < b) != desc
+		}
+		return false
+	})
+}
+
+func (f *fakeTable) requestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+// handle registers f as the incident table of mux.
+func (f *fakeTable) handle(mux *http.ServeMux) {
+	mux.Handle("/incident.do", f)
+	mux.Handle("/api/now/table/incident", f)
+	mux.Handle("/api/now/table/incident/", f)
+}
+
+func newFakeTable(t *testing.T, backend Backend) *fakeTable {
+	f := &fakeTable{t: t, backend: backend}
+	// sys_ids deliberately sort in another order than numbers.
+	for i, sysID := range []string{"e5", "a1", "d4", "b2", "c3", "f6", "g7"} {
+		f.records = append(f.records, map[string]interface{}{
+			"sys_id": sysID,
+			"number": "INC" + strconv.Itoa(i),
+			"a":      strconv.Itoa(i % 2),
+			"b":      strconv.Itoa(i % 3),
+		})
+	}
+	return f
+}
+
+func TestTableService_ListAll_keyset(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *Query
+	}{
+		{"all", nil},
+		{"and", NewQuery().Eq("a", "1")},
+		{"nq", NewQuery().Eq("a", "1").NQ().Eq("b", "2")},
+		{"or and nq", NewQuery().Eq("a", "0").Or().Eq("b", "1").NQ().Eq("number", "INC3")},
+		{"ordered", NewQuery().Eq("a", "0").NQ().Eq("b", "1").OrderByDesc("number")},
+	}
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mux := setup(t, WithBackend(backend))
+				f := newFakeTable(t, backend)
+				f.handle(mux)
+
+				// The records expected in sys_id order.
+				var want []string
+				for _, rec := range f.records {
+					if ok, _ := tt.q.Match(rec); ok {
+						want = append(want, rec["sys_id"].(string))
+					}
+				}
+				sort.Strings(want)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				incs, _, err := client.Incidents.ListAll(ctx, ListOptions{Query: tt.q, Limit: "2"})
+				if err != nil {
+					t.Fatalf("backend %v: ListAll returned error: %v", backend, err)
+				}
+				var got []string
+				for _, inc := range incs {
+					got = append(got, inc.GetSysID())
+				}
+				if !equalStrings(got, want) {
+					t.Errorf("backend %v: ListAll returned %v, want %v", backend, got, want)
+				}
+				if max := len(want)/2 + 1; f.requestCount() > max {
+					t.Errorf("backend %v: ListAll made %d requests, want at most %d", backend, f.requestCount(), max)
+				}
+			})
+		}
+	}
+}
+
+func TestKeysetQuery(t *testing.T) {
+	tests := []struct {
+		encoded, after, want string
+	}{
+		{"", "", "ORDERBYsys_id"},
+		{"", "s1", "sys_id>s1^ORDERBYsys_id"},
+		{"a=1^NQb=2", "s1", "a=1^sys_id>s1^NQb=2^sys_id>s1^ORDERBYsys_id"},
+		{"a=1^ORa=2^ORDERBYDESCnumber", "s1", "a=1^ORa=2^sys_id>s1^ORDERBYsys_id^ORDERBYDESCnumber"},
+		{"ORDERBYnumber", "s1", "sys_id>s1^ORDERBYsys_id^ORDERBYnumber"},
+		{"active=true^EQ", "", "active=true^ORDERBYsys_id"},
+		// Operators that ParseQuery does not support are kept as they are.
+		{
+			"opened_atONLast 30 days@javascript:gs.beginningOfLast30Days()@javascript:gs.endOfLast30Days()",
+			"s1",
+			"opened_atONLast 30 days@javascript:gs.beginningOfLast30Days()@javascript:gs.endOfLast30Days()^sys_id>s1^ORDERBYsys_id",
+		},
+		{"assigned_toDYNAMIC90d1921e5f510100a9ad2572f2b477fe", "s1", "assigned_toDYNAMIC90d1921e5f510100a9ad2572f2b477fe^sys_id>s1^ORDERBYsys_id"},
+		{"sys_created_onRELATIVEGT@dayofweek@ago@3^NQactive=false", "s1", "sys_created_onRELATIVEGT@dayofweek@ago@3^sys_id>s1^NQactive=false^sys_id>s1^ORDERBYsys_id"},
+		{"sys_class_nameINSTANCEOFtask^ORDERBYDESCnumber", "s1", "sys_class_nameINSTANCEOFtask^sys_id>s1^ORDERBYsys_id^ORDERBYDESCnumber"},
+	}
+	for _, tt := range tests {
+		got, err := keysetQuery(tt.encoded, tt.after)
+		if err != nil {
+			t.Errorf("keysetQuery(%q, %q) returned error: %v", tt.encoded, tt.after, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("keysetQuery(%q, %q) = %q, want %q", tt.encoded, tt.after, got, tt.want)
+		}
+	}
+
+	if _, err := keysetQuery("", "s1^NQactive=true"); err == nil {
+		t.Errorf("keysetQuery with a hostile sys_id returned no error")
+	}
+}
+
+func TestTableService_ListAll_operators(t *testing.T) {
+	queries := []string{
+		"opened_atONLast 30 days@javascript:gs.beginningOfLast30Days()@javascript:gs.endOfLast30Days()",
+		"assigned_toDYNAMIC90d1921e5f510100a9ad2572f2b477fe",
+		"sys_created_onRELATIVEGT@dayofweek@ago@3",
+		"sys_class_nameINSTANCEOFtask",
+	}
+	for _, backend := range []Backend{BackendJSONv2, BackendTable} {
+		for _, q := range queries {
+			client, mux := setup(t, WithBackend(backend))
+			var got []string
+			h := func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.URL.Query().Get("sysparm_query"))
+				recs := []map[string]interface{}{{"sys_id": "s1"}, {"sys_id": "s2"}}
+				if len(got) > 1 {
+					recs = nil
+				}
+				if backend == BackendTable {
+					if recs == nil {
+						recs = []map[string]interface{}{}
+					}
+					writeJSON(t, w, result(recs))
+				} else {
+					writeJSON(t, w, records(recs...))
+				}
+			}
+			mux.HandleFunc("/incident.do", h)
+			mux.HandleFunc("/api/now/table/incident", h)
+
+			opts := ListOptions{Limit: "2"}
+			opts.SysparmQuery = q
+			incs, _, err := client.Incidents.ListAll(context.Background(), opts)
+			if err != nil {
+				t.Errorf("backend %v: ListAll(%q) returned error: %v", backend, q, err)
+				continue
+			}
+			if len(incs) != 2 {
+				t.Errorf("backend %v: ListAll(%q) returned %d records, want 2", backend, q, len(incs))
+			}
+			want := []string{q + "^ORDERBYsys_id", q + "^sys_id>s2^ORDERBYsys_id"}
+			if !equalStrings(got, want) {
+				t.Errorf("backend %v: ListAll sent sysparm_query %q, want %q", backend, got, want)
+			}
+		}
+	}
+}
@@ -6,12 +6,30 @@
 package servicenow
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// ServiceNow layouts of date and time values. Values are given in UTC, and
+// display values in the time zone of the user.
+const (
+	timestampLayout = "2006-01-02 15:04:05"
+	dateLayout      = "2006-01-02"
+)
+
 // Timestamp represents a time that can be unmarshalled from a JSON string
-// formatted as either an RFC3339 or Unix timestamp.
+// formatted as a ServiceNow date and time ("2006-01-02 15:04:05", in UTC), a
+// ServiceNow date, RFC3339 or a Unix timestamp, or from an object holding the
+// value and display value of a field. An empty string is the zero Timestamp.
+// Clients parse display values in other layouts set with WithDisplayLayout.
+//
+// Timestamps are marshalled in the ServiceNow format, in UTC, and the zero
+// Timestamp as an empty string, which clears the field.
 type Timestamp struct {
 	time.Time
 }
@@ -21,19 +39,218 @@ func (t Timestamp) String() string {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-// Time is expected in RFC3339 or Unix format.
+// Time is expected in a ServiceNow, RFC3339 or Unix format.
 func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
+	data = bytes.TrimSpace(data)
 	str := string(data)
+	if str == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		var pair struct {
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(data, &pair); err != nil {
+			return err
+		}
+		if len(pair.Value) == 0 {
+			t.Time = time.Time{}
+			return nil
+		}
+		return t.UnmarshalJSON(pair.Value)
+	}
+
 	i, err := strconv.ParseInt(str, 10, 64)
 	if err == nil {
 		t.Time = time.Unix(i, 0)
-	} else {
-		t.Time, err = time.Parse(`"`+time.RFC3339+`"`, str)
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if str == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range []string{timestampLayout, dateLayout} {
+		if parsed, err := time.Parse(layout, str); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	t.Time, err = time.Parse(time.RFC3339, str)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
 	}
-	return
+	return json.Marshal(t.UTC().Format(timestampLayout))
 }
 
 // Equal reports whether t and u are equal based on time.Equal
 func (t Timestamp) Equal(u Timestamp) bool {
 	return t.Time.Equal(u.Time)
 }
+
+// localize reinterprets the wall clock of t, which was parsed from a display
+// value, in loc. Display values are given in the time zone of the user, but
+// are parsed as UTC, as they carry no time zone.
+func (t *Timestamp) localize(loc *time.Location) {
+	if t.IsZero() || t.Location() != time.UTC {
+		return
+	}
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	t.Time = time.Date(y, mo, d, h, mi, s, t.Nanosecond(), loc)
+}
+
+// localizer is implemented by values that hold display values of date and
+// time fields.
+type localizer interface {
+	localize(loc *time.Location)
+}
+
+// localizeValues localizes the Timestamps reachable from v, which is
+// typically a pointer to a slice of records decoded from display values.
+func localizeValues(v reflect.Value, loc *time.Location) {
+	visitValues(v, func(v reflect.Value) bool {
+		l, ok := v.Addr().Interface().(localizer)
+		if ok {
+			l.localize(loc)
+		}
+		return ok
+	})
+}
+
+// visitValues calls visit with the addressable values reachable from v
+// through pointers, interfaces, slices, arrays and exported struct fields. The
+// values for which visit returns true are not descended into.
+func visitValues(v reflect.Value, visit func(v reflect.Value) bool) {
+	if v.CanAddr() && visit(v) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			visitValues(v.Elem(), visit)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			visitValues(v.Index(i), visit)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				visitValues(v.Field(i), visit)
+			}
+		}
+	}
+}
+
+// fieldTimestampType is the type of the Field of date and time fields.
+var fieldTimestampType = reflect.TypeOf(Field[Timestamp]{})
+
+// timestampFields caches the lower case JSON names of the date and time
+// fields of record types, by type.
+var timestampFields sync.Map // map[reflect.Type]map[string]bool
+
+// timestampFieldNames returns the lower case JSON names of the fields of
+// type Timestamp or Field[Timestamp], or pointers to them, of the struct type
+// t, including the fields promoted from the structs it embeds.
+func timestampFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := timestampFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct && ft != timestampType {
+			for n := range timestampFieldNames(ft) {
+				names[n] = true
+			}
+			continue
+		}
+		if !f.IsExported() || (ft != timestampType && ft != fieldTimestampType) {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	timestampFields.Store(t, names)
+	return names
+}
+
+// parseDisplayTimes rewrites the date and time display values of data, a
+// record of type t or *t, from the first of layouts that matches them to the
+// layout Timestamp decodes. Display values that match neither layouts nor
+// the default layouts are removed, leaving their fields unset.
+func parseDisplayTimes(data json.RawMessage, t reflect.Type, layouts []string) (json.RawMessage, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return data, nil
+	}
+	names := timestampFieldNames(t)
+	if len(names) == 0 {
+		return data, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	changed := false
+	for k, raw := range m {
+		var s string
+		if !names[strings.ToLower(k)] || json.Unmarshal(raw, &s) != nil || s == "" {
+			continue
+		}
+		parsed, ok := parseDisplayTime(s, layouts)
+		switch {
+		case !ok:
+			delete(m, k)
+		case parsed != s:
+			m[k], _ = json.Marshal(parsed)
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(m)
+}
+
+// parseDisplayTime returns the display value s in the layout Timestamp
+// decodes, parsing it with the first of layouts that matches it or with the
+// default layouts, and reports whether any of them did.
+func parseDisplayTime(s string, layouts []string) (string, bool) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(timestampLayout), true
+		}
+	}
+	for _, layout := range []string{timestampLayout, dateLayout, time.RFC3339} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTableService_List_displayLayout(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		openedAt *time.Time // nil if unset
+		dueDate  *time.Time
+	}{
+		{
+			name: "default layouts",
+		},
+		{
+			name:     "custom layouts",
+			opts:     []ClientOption{WithDisplayLayout("01/02/2006 03:04:05 PM", "01/02/2006"), WithLocation(loc)},
+			openedAt: timePtr(time.Date(2024, 1, 2, 22, 0, 0, 0, loc)),
+			dueDate:  timePtr(time.Date(2024, 3, 4, 0, 0, 0, 0, loc)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t, tt.opts...)
+			mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, records(map[string]interface{}{
+					"number":         "INC1",
+					"opened_at":      "01/02/2024 10:00:00 PM",
+					"due_date":       "03/04/2024",
+					"closed_at":      "not a date",
+					"sys_updated_on": "2024-01-02 03:04:05",
+					"u_date":         "01/02/2024 10:00:00 PM",
+				}))
+			})
+
+			incs, _, err := client.Incidents.List(context.Background(), ListOptions{DisplayValue: DisplayValueTrue})
+			if err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			inc := incs[0]
+			checkTime(t, "OpenedAt", inc.OpenedAt, tt.openedAt)
+			checkTime(t, "DueDate", inc.DueDate, tt.dueDate)
+			checkTime(t, "ClosedAt", inc.ClosedAt, nil)
+			if got := inc.SysUpdatedOn.Get(); got.Hour() != 3 || got.Minute() != 4 {
+				t.Errorf("SysUpdatedOn = %v, want 03:04", got)
+			}
+			if got := string(inc.Extra["u_date"]); got != `"01/02/2024 10:00:00 PM"` {
+				t.Errorf("Extra u_date = %s, want it unchanged", got)
+			}
+		})
+	}
+
+	// Values, as opposed to display values, must be in the ServiceNow layout.
+	client, mux := setup(t, WithDisplayLayout("01/02/2006 03:04:05 PM"))
+	mux.HandleFunc("/incident.do", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, records(map[string]interface{}{"opened_at": "01/02/2024 10:00:00 PM"}))
+	})
+	if _, _, err := client.Incidents.List(context.Background(), ListOptions{}); err == nil {
+		t.Errorf("List of values in a display layout returned no error")
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func checkTime(t *testing.T, name string, f *Field[Timestamp], want *time.Time) {
+	t.Helper()
+	if want == nil {
+		if f.IsSet() {
+			t.Errorf("%s = %v, want unset", name, f)
+		}
+		return
+	}
+	if got := f.Get(); !got.Equal(Timestamp{*want}) {
+		t.Errorf("%s = %v, want %v", name, got, *want)
+	}
+}